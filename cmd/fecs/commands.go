@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func runStats(out io.Writer, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print output as JSON")
+
+	files, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	snap, err := loadSnapshot(files[0])
+	if err != nil {
+		return err
+	}
+
+	type typeStats struct {
+		Type  string `json:"type"`
+		Count int    `json:"count"`
+	}
+
+	counts := make(map[string]int, 16)
+	components := 0
+
+	for i := range snap.Entities {
+		for j := range snap.Entities[i].Components {
+			counts[typeName(&snap.Entities[i].Components[j])]++
+			components++
+		}
+	}
+
+	types := make([]typeStats, 0, len(counts))
+	for name, count := range counts {
+		types = append(types, typeStats{name, count})
+	}
+
+	sort.Slice(types, func(a, b int) bool { return types[a].Type < types[b].Type })
+
+	if *asJSON {
+		return writeJSON(out, struct {
+			Scene      fecs.SceneID `json:"scene"`
+			Entities   int          `json:"entities"`
+			Components int          `json:"components"`
+			Types      []typeStats  `json:"types"`
+		}{snap.Scene, len(snap.Entities), components, types})
+	}
+
+	tw := newTable(out)
+	fmt.Fprintf(tw, "scene\t%x\n", snap.Scene)
+	fmt.Fprintf(tw, "entities\t%d\n", len(snap.Entities))
+	fmt.Fprintf(tw, "components\t%d\n", components)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "TYPE\tCOUNT")
+
+	for _, t := range types {
+		fmt.Fprintf(tw, "%s\t%d\n", t.Type, t.Count)
+	}
+
+	return tw.Flush()
+}
+
+func runEntities(out io.Writer, args []string) error {
+	fs := flag.NewFlagSet("entities", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print output as JSON")
+	with := fs.String("with", "", "comma separated list of component types entities must have")
+
+	files, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	snap, err := loadSnapshot(files[0])
+	if err != nil {
+		return err
+	}
+
+	var filter []string
+	if *with != "" {
+		filter = strings.Split(*with, ",")
+	}
+
+	type entityRow struct {
+		ID    string   `json:"id"`
+		Types []string `json:"types"`
+	}
+
+	rows := make([]entityRow, 0, len(snap.Entities))
+
+	for i := range snap.Entities {
+		ent := &snap.Entities[i]
+
+		if hasTypes(ent, filter) {
+			rows = append(rows, entityRow{ent.ID, typeNames(ent)})
+		}
+	}
+
+	if *asJSON {
+		return writeJSON(out, rows)
+	}
+
+	tw := newTable(out)
+	fmt.Fprintln(tw, "ID\tCOMPONENTS")
+
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%s\n", row.ID, strings.Join(row.Types, ","))
+	}
+
+	return tw.Flush()
+}
+
+func runShow(out io.Writer, args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print output as JSON")
+
+	pos, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	eid, err := fecs.ParseEntityID(pos[1])
+	if err != nil {
+		return err
+	}
+
+	snap, err := loadSnapshot(pos[0])
+	if err != nil {
+		return err
+	}
+
+	ent, ok := snap.Entity(&eid)
+	if !ok {
+		return fmt.Errorf("entity %s not found in %s", eid.String(), pos[0])
+	}
+
+	if *asJSON {
+		return writeJSON(out, ent)
+	}
+
+	tw := newTable(out)
+	fmt.Fprintf(tw, "entity\t%s\n", ent.ID)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "TYPE\tID\tVALUE")
+
+	for i := range ent.Components {
+		comp := &ent.Components[i]
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", typeName(comp), comp.ID, compact(comp.Value))
+	}
+
+	return tw.Flush()
+}
+
+func runDiff(out io.Writer, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print output as JSON")
+
+	files, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	a, err := loadSnapshot(files[0])
+	if err != nil {
+		return err
+	}
+
+	b, err := loadSnapshot(files[1])
+	if err != nil {
+		return err
+	}
+
+	diffs := diffSnapshots(a, b)
+
+	if *asJSON {
+		return writeJSON(out, diffs)
+	}
+
+	tw := newTable(out)
+	fmt.Fprintln(tw, "CHANGE\tENTITY\tTYPE\tVALUE")
+
+	for _, d := range diffs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Change, d.Entity, d.Type, d.describe())
+	}
+
+	return tw.Flush()
+}
+
+// snapshotDiff describes a single difference between two snapshots.
+type snapshotDiff struct {
+	Change string          `json:"change"`
+	Entity string          `json:"entity"`
+	Type   string          `json:"type,omitempty"`
+	Old    json.RawMessage `json:"old,omitempty"`
+	New    json.RawMessage `json:"new,omitempty"`
+}
+
+func (d *snapshotDiff) describe() string {
+	switch {
+	case d.Old != nil && d.New != nil:
+		return compact(d.Old) + " -> " + compact(d.New)
+	case d.Old != nil:
+		return compact(d.Old)
+	default:
+		return compact(d.New)
+	}
+}
+
+func diffSnapshots(a, b *fecs.Snapshot) []snapshotDiff {
+	out := make([]snapshotDiff, 0, 16)
+
+	index := make(map[string]*fecs.SnapshotEntity, len(b.Entities))
+	for i := range b.Entities {
+		index[b.Entities[i].ID] = &b.Entities[i]
+	}
+
+	for i := range a.Entities {
+		old := &a.Entities[i]
+		cur, ok := index[old.ID]
+
+		if !ok {
+			out = append(out, snapshotDiff{Change: "-entity", Entity: old.ID})
+			continue
+		}
+
+		delete(index, old.ID)
+
+		for j := range old.Components {
+			oc := &old.Components[j]
+
+			if nc, ok := cur.Component(oc.Type); !ok {
+				out = append(out, snapshotDiff{Change: "-component", Entity: old.ID, Type: typeName(oc), Old: oc.Value})
+			} else if !bytes.Equal(oc.Value, nc.Value) {
+				out = append(out, snapshotDiff{Change: "~component", Entity: old.ID, Type: typeName(oc), Old: oc.Value, New: nc.Value})
+			}
+		}
+
+		for j := range cur.Components {
+			nc := &cur.Components[j]
+
+			if _, ok := old.Component(nc.Type); !ok {
+				out = append(out, snapshotDiff{Change: "+component", Entity: cur.ID, Type: typeName(nc), New: nc.Value})
+			}
+		}
+	}
+
+	// Walk b in order so that added entities are reported deterministically.
+	for i := range b.Entities {
+		if _, ok := index[b.Entities[i].ID]; ok {
+			out = append(out, snapshotDiff{Change: "+entity", Entity: b.Entities[i].ID})
+		}
+	}
+
+	return out
+}
+
+func loadSnapshot(path string) (*fecs.Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	snap, err := fecs.ReadSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return snap, nil
+}
+
+// hasTypes tests whether the given entity has components matching all the
+// given type names or ids.
+func hasTypes(ent *fecs.SnapshotEntity, types []string) bool {
+outer:
+	for _, t := range types {
+		t = strings.TrimSpace(t)
+
+		for i := range ent.Components {
+			if typeMatches(&ent.Components[i], t) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func typeMatches(comp *fecs.SnapshotComponent, name string) bool {
+	if comp.Name == name || comp.Type.String() == name {
+		return true
+	}
+
+	v, err := strconv.ParseUint(name, 16, 8)
+	return err == nil && fecs.ComponentType(v) == comp.Type
+}
+
+func typeName(comp *fecs.SnapshotComponent) string {
+	if comp.Name != "" {
+		return comp.Name
+	}
+
+	return comp.Type.String()
+}
+
+func typeNames(ent *fecs.SnapshotEntity) []string {
+	out := make([]string, len(ent.Components))
+
+	for i := range ent.Components {
+		out[i] = typeName(&ent.Components[i])
+	}
+
+	sort.Strings(out)
+
+	return out
+}
+
+func compact(raw json.RawMessage) string {
+	var buf bytes.Buffer
+
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+
+	return buf.String()
+}
+
+func newTable(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+}
+
+func writeJSON(out io.Writer, value any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(value)
+}
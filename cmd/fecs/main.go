@@ -0,0 +1,112 @@
+// Command fecs inspects scene snapshot files written by fecs.Snapshot.Write.
+//
+// Usage:
+//
+//	fecs stats [-json] <file>
+//	fecs entities [-json] [-with Type,...] <file>
+//	fecs show [-json] <file> <entity-id>
+//	fecs diff [-json] <file-a> <file-b>
+//
+// Component types may be given either by the name they were registered with or
+// by their "ct-<hex>" id.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(out io.Writer, args []string) error
+}
+
+var commands = []command{
+	{"stats", "stats [-json] <file>", "print entity and component counts", runStats},
+	{"entities", "entities [-json] [-with Type,...] <file>", "list entities and their component types", runEntities},
+	{"show", "show [-json] <file> <entity-id>", "print the components of a single entity", runShow},
+	{"diff", "diff [-json] <file-a> <file-b>", "compare the contents of two snapshots", runDiff},
+}
+
+// errUsage is returned by commands that were given invalid arguments.
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(out, errOut io.Writer, args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(errOut)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		if err := cmd.run(out, args[1:]); err != nil {
+			if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintln(errOut, "usage: fecs", cmd.usage)
+				return 2
+			}
+
+			fmt.Fprintln(errOut, "fecs:", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	fmt.Fprintf(errOut, "fecs: unknown command %q\n", args[0])
+	printUsage(errOut)
+	return 2
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: fecs <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// parseArgs parses the given arguments with the given FlagSet, allowing flags
+// to appear after positional arguments, and returns the positional arguments.
+//
+// If the number of positional arguments does not match the given count, errUsage
+// is returned.
+func parseArgs(fs *flag.FlagSet, args []string, count int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+
+		if len(args) == 0 {
+			break
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if len(positional) != count {
+		return nil, errUsage
+	}
+
+	return positional, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRun runs each command against the snapshots in testdata, comparing the
+// output with the matching .golden file.
+func TestRun(t *testing.T) {
+	before, after := filepath.Join("testdata", "before.json"), filepath.Join("testdata", "after.json")
+
+	tests := []struct {
+		golden string
+		args   []string
+	}{
+		{"stats", []string{"stats", before}},
+		{"stats-json", []string{"stats", "-json", before}},
+		{"entities", []string{"entities", before}},
+		{"entities-filtered", []string{"entities", "-with", "Position,ct-9", before}},
+		{"entities-json", []string{"entities", before, "-with", "2", "-json"}},
+		{"show", []string{"show", before, "eid-1a-0-1"}},
+		{"show-json", []string{"show", "-json", after, "eid-1a-3-1"}},
+		{"diff", []string{"diff", before, after}},
+		{"diff-json", []string{"diff", "-json", before, after}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.golden, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("testdata", test.golden+".golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out, errOut bytes.Buffer
+
+			if code := run(&out, &errOut, test.args); code != 0 {
+				t.Fatalf("run(%q) = %d: %s", test.args, code, errOut.String())
+			}
+
+			if out.String() != string(want) {
+				t.Errorf("run(%q) printed:\n%s\nwant:\n%s", test.args, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	before := filepath.Join("testdata", "before.json")
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		errOut string
+	}{
+		{"no command", nil, 2, "usage: fecs <command>"},
+		{"help", []string{"help"}, 2, "usage: fecs <command>"},
+		{"unknown command", []string{"frobnicate"}, 2, `unknown command "frobnicate"`},
+		{"missing argument", []string{"show", before}, 2, "usage: fecs show"},
+		{"extra argument", []string{"stats", before, before}, 2, "usage: fecs stats"},
+		{"unknown flag", []string{"diff", "-nope", before, before}, 1, "flag provided but not defined"},
+		{"help flag", []string{"entities", "-h"}, 2, "usage: fecs entities"},
+		{"missing file", []string{"stats", filepath.Join("testdata", "missing.json")}, 1, "missing.json"},
+		{"not a snapshot", []string{"stats", filepath.Join("testdata", "stats.golden")}, 1, "failed to read scene snapshot"},
+		{"invalid entity", []string{"show", before, "entity-1"}, 1, "fecs:"},
+		{"unknown entity", []string{"show", before, "eid-1a-9-1"}, 1, "entity eid-1a-9-1 not found"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+
+			if code := run(&out, &errOut, test.args); code != test.code {
+				t.Errorf("run(%q) = %d, want %d", test.args, code, test.code)
+			}
+
+			if !strings.Contains(errOut.String(), test.errOut) {
+				t.Errorf("run(%q) printed %q, want it to contain %q", test.args, errOut.String(), test.errOut)
+			}
+
+			if out.Len() > 0 {
+				t.Errorf("run(%q) wrote %q to stdout", test.args, out.String())
+			}
+		})
+	}
+}
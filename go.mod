@@ -1,3 +1,3 @@
 module github.com/Foxcapades/go-ecs-toy
 
-go 1.21
\ No newline at end of file
+go 1.23
\ No newline at end of file
@@ -0,0 +1,23 @@
+package fecs
+
+import "time"
+
+// Clock defines a source of the current time.
+//
+// Clock allows time-dependent types such as Runner to be driven
+// deterministically, for example by a fake clock in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// SystemClock returns a Clock backed by time.Now.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
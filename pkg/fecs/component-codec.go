@@ -0,0 +1,54 @@
+package fecs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ComponentDecoder defines a function that reconstructs a Component from the
+// binary form produced by that Component's MarshalBinary method.
+type ComponentDecoder = func(data []byte) (Component, error)
+
+var (
+	componentDecoderLock sync.RWMutex
+	componentDecoders    = make(map[ComponentType]ComponentDecoder, 16)
+)
+
+// RegisterComponentDecoder registers the ComponentDecoder that will be used to
+// reconstruct Components of the given ComponentType from their binary form.
+//
+// Components that are to be sent between scenes must implement
+// encoding.BinaryMarshaler and have a ComponentDecoder registered for their
+// ComponentType on the receiving end.
+//
+// Registering a second ComponentDecoder for the same ComponentType replaces the
+// first.
+func RegisterComponentDecoder(ct ComponentType, decoder ComponentDecoder) {
+	componentDecoderLock.Lock()
+	defer componentDecoderLock.Unlock()
+
+	componentDecoders[ct] = decoder
+}
+
+// decodeComponent reconstructs a Component of the given ComponentType from the
+// given binary data using the registered ComponentDecoder for that type.
+func decodeComponent(ct ComponentType, data []byte) (Component, error) {
+	componentDecoderLock.RLock()
+	decoder, ok := componentDecoders[ct]
+	componentDecoderLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no component decoder registered for component type %s", ct.String())
+	}
+
+	comp, err := decoder(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode component of type %s: %w", ct.String(), err)
+	}
+
+	if comp.Type() != ct {
+		return nil, fmt.Errorf("component decoder for type %s returned a component of type %s: %w", ct.String(), comp.Type().String(), ErrWrongComponentType)
+	}
+
+	return comp, nil
+}
@@ -1,6 +1,51 @@
 package fecs
 
-import "fmt"
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
+)
+
+// componentIDBinarySize is the length of the binary form of a ComponentID.
+const componentIDBinarySize = 12
+
+var componentIdRegex = regexp.MustCompile(`^cid-([0-9a-fA-F]{1,2})-([0-9a-fA-F]{1,8})-([0-9a-fA-F]{1,8})$`)
+
+// ParseComponentID parses the given stringified ComponentID value into a real
+// ComponentID instance.
+//
+// ComponentID string values resemble "cid-ff-ffff-ffff", where the first group
+// of 'f' characters in the example could be any hex string up to 2 characters
+// in length, and the remaining groups could be any hex string up to 8
+// characters in length.
+func ParseComponentID(idString string) (ComponentID, error) {
+	matches := componentIdRegex.FindStringSubmatch(idString)
+
+	if len(matches) != 4 {
+		return ComponentID{}, fmt.Errorf("invalid component id string: %s", idString)
+	}
+
+	var out ComponentID
+
+	ctype, err := futil.ParseHexUint32(matches[1])
+	if err != nil {
+		return ComponentID{}, fmt.Errorf("invalid component id string: %s: %w", idString, err)
+	}
+
+	out.ctype = ComponentType(ctype)
+
+	if out.index, err = futil.ParseHexUint32(matches[2]); err != nil {
+		return ComponentID{}, fmt.Errorf("invalid component id string: %s: %w", idString, err)
+	}
+
+	if out.version, err = futil.ParseHexUint32(matches[3]); err != nil {
+		return ComponentID{}, fmt.Errorf("invalid component id string: %s: %w", idString, err)
+	}
+
+	return out, nil
+}
 
 type ComponentID struct {
 	index   uint32
@@ -9,7 +54,7 @@ type ComponentID struct {
 }
 
 func (c *ComponentID) init(idx uint32, ct ComponentType) {
-	if c.index != 0 {
+	if c.version > 0 && c.isActive(idx) {
 		panic("attempted to initialize a component id more than once")
 	}
 
@@ -26,6 +71,12 @@ func (c *ComponentID) clear() {
 	c.index = ^c.index
 }
 
+// Type returns the ComponentType of the Component identified by this
+// ComponentID.
+func (c *ComponentID) Type() ComponentType {
+	return c.ctype
+}
+
 func (c *ComponentID) Equals(other *ComponentID) bool {
 	return c.ctype == other.ctype &&
 		c.index == other.index &&
@@ -33,5 +84,76 @@ func (c *ComponentID) Equals(other *ComponentID) bool {
 }
 
 func (c *ComponentID) String() string {
-	return fmt.Sprintf("cid-%x-%x-%x", c.ctype, c.index, c.version)
+	return fmt.Sprintf("cid-%x-%x-%x", uint8(c.ctype), c.index, c.version)
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the same form as
+// String.
+func (c ComponentID) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the form parsed
+// by ParseComponentID.
+func (c *ComponentID) UnmarshalText(text []byte) error {
+	id, err := ParseComponentID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = id
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, producing the String form of this
+// ComponentID as a JSON string.
+func (c ComponentID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a JSON string in the
+// form parsed by ParseComponentID.
+//
+// A JSON null leaves this ComponentID unchanged.
+func (c *ComponentID) UnmarshalJSON(data []byte) error {
+	text, err := unquoteID(data, "component")
+	if err != nil || text == nil {
+		return err
+	}
+
+	return c.UnmarshalText(text)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The binary form of a ComponentID is 12 bytes long, holding the ComponentType,
+// index and version values of the ComponentID in that order, each as a big
+// endian uint32.
+func (c ComponentID) MarshalBinary() ([]byte, error) {
+	out := make([]byte, componentIDBinarySize)
+
+	binary.BigEndian.PutUint32(out[0:], uint32(c.ctype))
+	binary.BigEndian.PutUint32(out[4:], c.index)
+	binary.BigEndian.PutUint32(out[8:], c.version)
+
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, accepting the form
+// produced by MarshalBinary.
+func (c *ComponentID) UnmarshalBinary(data []byte) error {
+	if len(data) != componentIDBinarySize {
+		return fmt.Errorf("invalid component id binary length %d, expected %d", len(data), componentIDBinarySize)
+	}
+
+	ctype := binary.BigEndian.Uint32(data[0:])
+	if ctype > 0xff {
+		return fmt.Errorf("invalid component id binary value: component type %d out of range", ctype)
+	}
+
+	c.ctype = ComponentType(ctype)
+	c.index = binary.BigEndian.Uint32(data[4:])
+	c.version = binary.BigEndian.Uint32(data[8:])
+
+	return nil
 }
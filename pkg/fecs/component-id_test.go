@@ -0,0 +1,35 @@
+package fecs_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func TestComponentIDMarshaling(t *testing.T) {
+	scene := fecs.NewScene()
+	id := scene.NewEntity()
+	scene.AttachComponent(&id, newPosition(0, 0))
+	cid := scene.AttachComponent(&id, newHealth(1))
+
+	testIDRoundTrip(t, cid, cid.String())
+
+	largest, err := fecs.ParseComponentID("cid-ff-ffffffff-ffffffff")
+	mustNoErr(t, err)
+	testIDRoundTrip(t, largest, "cid-ff-ffffffff-ffffffff")
+
+	if largest.Type() != 0xff {
+		t.Errorf("Type() = %v, want ct-ff", largest.Type())
+	}
+
+	// Component types are a single byte, but are written as a full uint32.
+	wide := binary.BigEndian.AppendUint32(nil, 0x100)
+	wide = append(wide, make([]byte, 8)...)
+
+	testIDRejects[fecs.ComponentID](t,
+		[]string{"", "eid-1-2-3", "cid-1-2", "cid-1-2-3-4", "cid-1-2-xyz", "cid-100-2-3", "cid-1-123456789-3"},
+		[]string{`1`, `{}`, `"cid-1-2"`, `"cid`},
+		[][]byte{nil, make([]byte, 11), make([]byte, 13), wide},
+	)
+}
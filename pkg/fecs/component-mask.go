@@ -39,6 +39,21 @@ func (c *componentMask) hasAll(other *componentMask) bool {
 		c.value[0]&other.value[0] == other.value[0]
 }
 
+func (c *componentMask) hasAny(other *componentMask) bool {
+	return c.value[3]&other.value[3] != 0 ||
+		c.value[2]&other.value[2] != 0 ||
+		c.value[1]&other.value[1] != 0 ||
+		c.value[0]&other.value[0] != 0
+}
+
+// merge adds all the ComponentTypes in the given mask to this mask.
+func (c *componentMask) merge(other *componentMask) {
+	c.value[3] |= other.value[3]
+	c.value[2] |= other.value[2]
+	c.value[1] |= other.value[1]
+	c.value[0] |= other.value[0]
+}
+
 func (c *componentMask) remove(cType ComponentType) {
 	if cType > 192 {
 		c.value[3] &= ^cType.toBitMask()
@@ -51,6 +66,10 @@ func (c *componentMask) remove(cType ComponentType) {
 	}
 }
 
+func (c *componentMask) isEmpty() bool {
+	return c.value == [4]uint64{}
+}
+
 func (c *componentMask) clear() {
 	c.value = [4]uint64{}
 }
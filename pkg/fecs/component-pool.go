@@ -13,6 +13,8 @@ func newComponentPool() *componentPool {
 		ids:  make([]ComponentID, componentPoolInitialCapacity),
 		pool: make([]Component, componentPoolInitialCapacity),
 		size: 0,
+
+		allocs: 1,
 	}
 }
 
@@ -21,6 +23,18 @@ type componentPool struct {
 	ids  []ComponentID
 	pool []Component
 	size uint32
+
+	// allocs counts the number of times the backing slices have been allocated.
+	allocs uint32
+
+	// floor is the highest version that was in use by any slot discarded by
+	// compaction.
+	floor uint32
+}
+
+// componentCount returns the number of live components in this componentPool.
+func (c *componentPool) componentCount() uint32 {
+	return c.size - uint32(c.free.Size())
 }
 
 func (c *componentPool) containsComponent(id *ComponentID) bool {
@@ -43,6 +57,14 @@ func (c *componentPool) newComponent(comp Component) ComponentID {
 	}
 }
 
+// reserve ensures that this componentPool has room for at least n new
+// components without needing to grow its backing slices.
+func (c *componentPool) reserve(n uint32) {
+	if free := uint32(c.free.Size()); n > free {
+		c._ensureCapacity(c.size + n - free)
+	}
+}
+
 func (c *componentPool) removeComponent(id *ComponentID) bool {
 	if !c.containsComponent(id) {
 		return false
@@ -57,7 +79,135 @@ func (c *componentPool) removeComponent(id *ComponentID) bool {
 	return true
 }
 
+func (c *componentPool) takeComponent(id *ComponentID) (Component, bool) {
+	if !c.containsComponent(id) {
+		return nil, false
+	}
+
+	comp := c.pool[id.index]
+	c.removeComponent(id)
+
+	return comp, true
+}
+
+// replaceComponent swaps the Component stored under the given ComponentID for
+// the given Component, returning the previously stored value.
+func (c *componentPool) replaceComponent(id *ComponentID, comp Component) (Component, bool) {
+	if !c.containsComponent(id) {
+		return nil, false
+	}
+
+	old := c.pool[id.index]
+	c.pool[id.index] = comp
+
+	return old, true
+}
+
+// restoreComponent puts the given Component back into this componentPool under
+// exactly the given ComponentID, including its version.
+//
+// The target slot must either be a cleared slot or the next slot past the end
+// of the pool.  If the slot is currently in use, or lies beyond the end of the
+// pool, this method returns false.
+func (c *componentPool) restoreComponent(id *ComponentID, comp Component) bool {
+	if id.index > c.size {
+		return false
+	}
+
+	if id.index == c.size {
+		c._ensureCapacity(c.size + 1)
+		c.size++
+	} else if c.ids[id.index].isActive(id.index) {
+		return false
+	} else {
+		removeFromStack(c.free, id.index)
+	}
+
+	c.ids[id.index] = *id
+	c.pool[id.index] = comp
+
+	return true
+}
+
+func (c *componentPool) reset() {
+	for i := uint32(0); i < c.size; i++ {
+		c.floor = max(c.floor, c.ids[i].version)
+	}
+
+	c.free = futil.NewStack[uint32]()
+	clear(c.ids)
+	clear(c.pool)
+	c.size = 0
+}
+
+func (c *componentPool) copyTo(dst componentStorage) componentStorage {
+	out, ok := dst.(*componentPool)
+	if !ok {
+		out = new(componentPool)
+	}
+
+	copyComponentPool(out, c)
+
+	return out
+}
+
+func (c *componentPool) allocations() uint32 {
+	return c.allocs
+}
+
+func (c *componentPool) validate(v *validator, ct ComponentType, owned map[ComponentID]EntityID) {
+	validateComponentSlots(v, ct, c.ids, len(c.pool), c.size, c.free, owned, func(i uint32) bool { return c.pool[i] != nil })
+
+	for i := uint32(0); i < min(c.size, uint32(len(c.pool)), uint32(len(c.ids))); i++ {
+		if c.ids[i].isActive(i) && c.pool[i] != nil && c.pool[i].Type() != ct {
+			v.report(i, "component %s holds a value of type %s", c.ids[i].String(), c.pool[i].Type().Name())
+		}
+	}
+}
+
+func (c *componentPool) compact(moved map[ComponentID]ComponentID) {
+	live := uint32(0)
+
+	for i := uint32(0); i < c.size; i++ {
+		if !c.ids[i].isActive(i) {
+			continue
+		}
+
+		if i != live {
+			old := c.ids[i]
+
+			c.ids[live] = ComponentID{index: live, version: c.ids[live].version + 1, ctype: old.ctype}
+			c.pool[live] = c.pool[i]
+
+			moved[old] = c.ids[live]
+		}
+
+		live++
+	}
+
+	for i := live; i < c.size; i++ {
+		c.floor = max(c.floor, c.ids[i].version)
+	}
+
+	capacity := max(live, componentPoolInitialCapacity)
+
+	newIDs := make([]ComponentID, capacity)
+	copy(newIDs, c.ids[:live])
+	c.ids = newIDs
+
+	newComponents := make([]Component, capacity)
+	copy(newComponents, c.pool[:live])
+	c.pool = newComponents
+
+	c.allocs++
+
+	c.size = live
+	c.free = futil.NewStack[uint32]()
+}
+
 func (c *componentPool) _append(comp Component) ComponentID {
+	c._ensureCapacity(c.size + 1)
+	c.ids[c.size] = ComponentID{index: ^c.size, version: c.floor}
 	c.ids[c.size].init(c.size, comp.Type())
 	c.pool[c.size] = comp
 	c.size++
@@ -65,7 +215,6 @@ func (c *componentPool) _append(comp Component) ComponentID {
 }
 
 func (c *componentPool) _overwrite(comp Component) ComponentID {
-	c._ensureCapacity(c.size + 1)
 	idx := c.free.Pop()
 
 	c.ids[idx].init(idx, comp.Type())
@@ -88,4 +237,6 @@ func (c *componentPool) _ensureCapacity(minimum uint32) {
 	newComponents := make([]Component, newSize)
 	copy(newComponents, c.pool)
 	c.pool = newComponents
+
+	c.allocs++
 }
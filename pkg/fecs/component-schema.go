@@ -0,0 +1,125 @@
+package fecs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ComponentMigration defines a function that upgrades the JSON form of a
+// Component from one schema version to the next.
+type ComponentMigration = func(value json.RawMessage) (json.RawMessage, error)
+
+// ComponentSchema describes how Components of a single ComponentType are
+// persisted and upgraded.
+//
+//	fecs.RegisterComponentSchema(PositionType, fecs.ComponentSchema{
+//		Version: 3,
+//		New:     func() fecs.Component { return new(Position) },
+//		Migrations: []fecs.ComponentMigration{
+//			migratePositionV1, // v1 -> v2
+//			migratePositionV2, // v2 -> v3
+//		},
+//	})
+type ComponentSchema struct {
+	// Version is the current schema version of the ComponentType, starting at 1.
+	Version int
+
+	// New creates the empty Component that the current JSON form of a persisted
+	// Component is decoded into.
+	New ComponentConstructor
+
+	// Migrations holds the functions that upgrade persisted Components to the
+	// current Version, where Migrations[0] upgrades from version 1 to version 2,
+	// Migrations[1] upgrades from version 2 to version 3, and so on.
+	Migrations []ComponentMigration
+}
+
+var (
+	componentSchemaLock sync.RWMutex
+	componentSchemas    = make(map[ComponentType]*ComponentSchema, 16)
+)
+
+// RegisterComponentSchema registers the ComponentSchema that will be used to
+// version and load persisted Components of the given ComponentType.
+//
+// If the given ComponentSchema has a Version less than 1, has no New function,
+// or does not have exactly Version-1 Migrations, this function will panic.
+//
+// Registering a second ComponentSchema for the same ComponentType replaces the
+// first.
+func RegisterComponentSchema(ct ComponentType, schema ComponentSchema) {
+	if schema.Version < 1 {
+		panic(fmt.Errorf("attempted to register component schema for type %s with invalid version %d", ct.String(), schema.Version))
+	}
+
+	if schema.New == nil {
+		panic(fmt.Errorf("attempted to register component schema for type %s with no constructor", ct.String()))
+	}
+
+	if len(schema.Migrations) != schema.Version-1 {
+		panic(fmt.Errorf("attempted to register component schema for type %s at version %d with %d migrations, expected %d", ct.String(), schema.Version, len(schema.Migrations), schema.Version-1))
+	}
+
+	schema.Migrations = append([]ComponentMigration(nil), schema.Migrations...)
+
+	componentSchemaLock.Lock()
+	defer componentSchemaLock.Unlock()
+
+	componentSchemas[ct] = &schema
+}
+
+// componentSchemaVersion returns the current schema version registered for the
+// given ComponentType, or 0 if no ComponentSchema is registered for it.
+func componentSchemaVersion(ct ComponentType) int {
+	componentSchemaLock.RLock()
+	defer componentSchemaLock.RUnlock()
+
+	if schema, ok := componentSchemas[ct]; ok {
+		return schema.Version
+	}
+
+	return 0
+}
+
+// loadComponent reconstructs a Component of the given ComponentType from the
+// given JSON form, written at the given schema version, upgrading it to the
+// current schema version first.
+func loadComponent(ct ComponentType, version int, value json.RawMessage) (Component, error) {
+	componentSchemaLock.RLock()
+	schema, ok := componentSchemas[ct]
+	componentSchemaLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("cannot load component of type %s: %w", ct.Name(), ErrNoComponentSchema)
+	}
+
+	// Values written before the ComponentType had a schema are treated as the
+	// first version.
+	version = max(version, 1)
+
+	if version > schema.Version {
+		return nil, fmt.Errorf("cannot load component of type %s written at version %d, the current version is %d: %w", ct.Name(), version, schema.Version, ErrSchemaVersion)
+	}
+
+	for ; version < schema.Version; version++ {
+		next, err := schema.Migrations[version-1](value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate component of type %s from version %d to %d: %w", ct.Name(), version, version+1, err)
+		}
+
+		value = next
+	}
+
+	comp := schema.New()
+
+	if err := json.Unmarshal(value, comp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal component of type %s: %w", ct.Name(), err)
+	}
+
+	if comp.Type() != ct {
+		return nil, fmt.Errorf("component schema for type %s created a component of type %s: %w", ct.Name(), comp.Type().Name(), ErrWrongComponentType)
+	}
+
+	return comp, nil
+}
@@ -0,0 +1,201 @@
+package fecs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+var manaType = fecs.NewNamedComponentType("Mana")
+
+// Mana is at schema version 3.
+//
+// Version 1 stored the current amount as "mp", version 2 renamed it to
+// "current" and version 3 added "max".
+type Mana struct {
+	Current int `json:"current"`
+	Max     int `json:"max"`
+}
+
+func (*Mana) Type() fecs.ComponentType {
+	return manaType
+}
+
+// manaMigrations records the migrations run, in order.
+var manaMigrations []string
+
+func init() {
+	fecs.RegisterComponentSchema(manaType, fecs.ComponentSchema{
+		Version: 3,
+		New:     func() fecs.Component { return new(Mana) },
+		Migrations: []fecs.ComponentMigration{
+			func(value json.RawMessage) (json.RawMessage, error) {
+				manaMigrations = append(manaMigrations, "v1")
+
+				var v1 struct{ MP int }
+				if err := json.Unmarshal(value, &v1); err != nil {
+					return nil, err
+				}
+
+				if v1.MP < 0 {
+					return nil, fmt.Errorf("negative mp %d", v1.MP)
+				}
+
+				return json.Marshal(map[string]int{"current": v1.MP})
+			},
+			func(value json.RawMessage) (json.RawMessage, error) {
+				manaMigrations = append(manaMigrations, "v2")
+
+				var v2 struct{ Current int }
+				if err := json.Unmarshal(value, &v2); err != nil {
+					return nil, err
+				}
+
+				return json.Marshal(Mana{v2.Current, max(v2.Current, 10)})
+			},
+		},
+	})
+}
+
+// manaSnapshot returns a version 1 Snapshot holding an entity with a Mana
+// Component for each of the given schema versions and values, where a version
+// of 0 leaves the schema out.
+func manaSnapshot(t *testing.T, components ...string) *fecs.Snapshot {
+	t.Helper()
+
+	var sb strings.Builder
+	sb.WriteString(`{"format":"fecs-snapshot","version":1,"scene":1,"entities":[`)
+
+	for i := 0; i < len(components); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		schema := ""
+		if components[i] != "0" {
+			schema = `"schema":` + components[i] + `,`
+		}
+
+		fmt.Fprintf(&sb, `{"id":"eid-1-%x-1","components":[{"id":"cid-1-%x-1","type":250,"name":"Mana",%s"value":%s}]}`, i/2, i/2, schema, components[i+1])
+	}
+
+	sb.WriteString(`]}`)
+
+	snap, err := fecs.ReadSnapshot(strings.NewReader(sb.String()))
+	mustNoErr(t, err)
+
+	return snap
+}
+
+func TestComponentSchemaMigration(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		value      string
+		want       Mana
+		migrations []string
+	}{
+		{"version 1", "1", `{"mp":4}`, Mana{4, 10}, []string{"v1", "v2"}},
+		{"no schema is version 1", "0", `{"mp":12}`, Mana{12, 12}, []string{"v1", "v2"}},
+		{"version 2", "2", `{"current":3}`, Mana{3, 10}, []string{"v2"}},
+		{"current version", "3", `{"current":1,"max":2}`, Mana{1, 2}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manaMigrations = nil
+			scene := fecs.NewScene()
+
+			ids, err := fecs.LoadSnapshot(scene, manaSnapshot(t, test.schema, test.value))
+			mustNoErr(t, err)
+
+			if len(ids) != 1 {
+				t.Fatalf("loaded %d entities, want 1", len(ids))
+			}
+
+			for _, id := range ids {
+				comp, ok := scene.GetComponentByType(&id, manaType)
+				if !ok || *comp.(*Mana) != test.want {
+					t.Errorf("loaded %+v, want %+v", comp, test.want)
+				}
+			}
+
+			if fmt.Sprint(manaMigrations) != fmt.Sprint(test.migrations) {
+				t.Errorf("ran migrations %v, want %v", manaMigrations, test.migrations)
+			}
+		})
+	}
+}
+
+func TestComponentSchemaErrors(t *testing.T) {
+	manaMigrations = nil
+	scene := fecs.NewScene()
+
+	snap := manaSnapshot(t,
+		"1", `{"mp":5}`,
+		"4", `{"current":1,"max":1}`,
+		"1", `{"mp":-1}`,
+		"3", `{"current":"lots"}`,
+	)
+
+	ids, err := fecs.LoadSnapshot(scene, snap)
+
+	// The good entity is still loaded.
+	if len(ids) != 1 || scene.Stats().Entities.Live != 1 {
+		t.Errorf("loaded %d entities, want 1", len(ids))
+	}
+
+	var loadErr *fecs.SnapshotLoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Entities) != 3 {
+		t.Fatalf("LoadSnapshot() = %v, want 3 failed entities", err)
+	}
+
+	if e := loadErr.Entities[0]; e.Entity != "eid-1-1-1" || !errors.Is(e, fecs.ErrSchemaVersion) {
+		t.Errorf("newer version: %v, want %v", e, fecs.ErrSchemaVersion)
+	}
+
+	if e := loadErr.Entities[1]; e.Entity != "eid-1-2-1" || !strings.Contains(e.Error(), "from version 1 to 2: negative mp -1") {
+		t.Errorf("failed migration: %v", e)
+	}
+
+	if e := loadErr.Entities[2]; e.Entity != "eid-1-3-1" || !strings.Contains(e.Error(), "failed to unmarshal") {
+		t.Errorf("bad value: %v", e)
+	}
+
+	// The migration chain stops at the failed step.
+	if want := "[v1 v2 v1]"; fmt.Sprint(manaMigrations) != want {
+		t.Errorf("ran migrations %v, want %s", manaMigrations, want)
+	}
+
+	if !errors.Is(err, fecs.ErrSchemaVersion) {
+		t.Errorf("LoadSnapshot() error does not wrap %v", fecs.ErrSchemaVersion)
+	}
+}
+
+func TestComponentSchemaUnregistered(t *testing.T) {
+	snap := manaSnapshot(t, "1", `{"mp":5}`)
+	snap.Entities[0].Components[0].Name = "Unregistered"
+
+	_, err := fecs.LoadSnapshot(fecs.NewScene(), snap)
+	if !errors.Is(err, fecs.ErrNoComponentSchema) {
+		t.Errorf("LoadSnapshot() = %v, want %v", err, fecs.ErrNoComponentSchema)
+	}
+}
+
+func TestRegisterComponentSchemaPanics(t *testing.T) {
+	newMana := func() fecs.Component { return new(Mana) }
+
+	mustPanic(t, "version 0", func() {
+		fecs.RegisterComponentSchema(manaType, fecs.ComponentSchema{Version: 0, New: newMana})
+	})
+	mustPanic(t, "no constructor", func() {
+		fecs.RegisterComponentSchema(manaType, fecs.ComponentSchema{Version: 1})
+	})
+	mustPanic(t, "missing migration", func() {
+		fecs.RegisterComponentSchema(manaType, fecs.ComponentSchema{Version: 2, New: newMana})
+	})
+}
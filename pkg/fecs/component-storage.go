@@ -0,0 +1,68 @@
+package fecs
+
+// componentStorage defines the operations a scene needs from the backing store
+// that holds all the Components of a single ComponentType.
+//
+// The default implementation is componentPool, which holds Components as
+// interface values.  Stores created by NewStore hold Components as contiguous
+// values instead.
+type componentStorage interface {
+	// componentCount returns the number of live components in the store.
+	componentCount() uint32
+
+	containsComponent(id *ComponentID) bool
+
+	getComponent(id *ComponentID) (Component, bool)
+
+	newComponent(comp Component) ComponentID
+
+	removeComponent(id *ComponentID) bool
+
+	// takeComponent removes the component identified by the given ComponentID
+	// from the store, returning a Component value that remains valid after the
+	// removal.
+	takeComponent(id *ComponentID) (Component, bool)
+
+	// replaceComponent swaps the Component stored under the given ComponentID
+	// for the given Component, returning a Component value holding the
+	// previously stored value.
+	replaceComponent(id *ComponentID, comp Component) (Component, bool)
+
+	// restoreComponent puts the given Component back into the store under
+	// exactly the given ComponentID, including its version.
+	restoreComponent(id *ComponentID, comp Component) bool
+
+	// reserve ensures that the store has room for at least n new components
+	// without needing to grow.
+	reserve(n uint32)
+
+	// reset removes every component from the store.
+	reset()
+
+	// compact packs the live components in the store into the front of the
+	// store, preserving their order, and shrinks the store to fit.
+	//
+	// Every component that is moved is given a new ComponentID, with a version
+	// greater than any version previously used in its new slot.  The old and new
+	// ComponentIDs of each moved component are recorded in the given map.
+	compact(moved map[ComponentID]ComponentID)
+
+	// copyTo deep copies this store into the given store, cloning each live
+	// Component, and returns it.
+	//
+	// If the given store is nil or is not of the same kind as this store, a new
+	// store is allocated and returned instead.
+	copyTo(dst componentStorage) componentStorage
+
+	// stats returns the current occupancy and estimated memory use of the store.
+	stats() PoolStats
+
+	// validate checks the internal consistency of the store, recording each
+	// violation found in the given validator.  The given map holds the
+	// ComponentIDs referenced by living entities.
+	validate(v *validator, ct ComponentType, owned map[ComponentID]EntityID)
+
+	// allocations returns the number of times the backing slices of the store
+	// have been allocated.
+	allocations() uint32
+}
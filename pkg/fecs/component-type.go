@@ -1,12 +1,35 @@
 package fecs
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
 
 const MaxComponentTypes uint8 = 255
 
 var currentComponentTypeId uint8 = 0
 
+// componentTypeLock guards the ComponentType registry, so that ComponentType
+// names may be looked up from any goroutine, such as by an HTTP handler
+// inspecting a Scene, while new ComponentTypes are being registered.
+var componentTypeLock sync.RWMutex
+
+var (
+	componentTypeNames   [256]string
+	componentTypesByName = make(map[string]ComponentType, 16)
+)
+
 func NewComponentType() ComponentType {
+	componentTypeLock.Lock()
+	defer componentTypeLock.Unlock()
+
+	return newComponentType()
+}
+
+// newComponentType registers a new ComponentType.  The caller must hold
+// componentTypeLock for writing.
+func newComponentType() ComponentType {
 	if currentComponentTypeId == 255 {
 		panic("attempted to register more than 255 component types")
 	}
@@ -15,8 +38,59 @@ func NewComponentType() ComponentType {
 	return ComponentType(currentComponentTypeId)
 }
 
+// NewNamedComponentType registers a new ComponentType with the given
+// human-readable name.
+//
+// Names are used by debugging and tooling output, and may be used to look up
+// the ComponentType with ComponentTypeByName.
+//
+// If the given name is empty or is already in use by another ComponentType,
+// this function will panic.
+func NewNamedComponentType(name string) ComponentType {
+	if name == "" {
+		panic("attempted to register a component type with an empty name")
+	}
+
+	componentTypeLock.Lock()
+	defer componentTypeLock.Unlock()
+
+	if ct, ok := componentTypesByName[name]; ok {
+		panic(fmt.Errorf("attempted to register component type name %q more than once (already used by %s)", name, ct.String()))
+	}
+
+	ct := newComponentType()
+	componentTypeNames[ct] = name
+	componentTypesByName[name] = ct
+
+	return ct
+}
+
+// ComponentTypeByName looks up the ComponentType that was registered with the
+// given name by NewNamedComponentType.
+func ComponentTypeByName(name string) (ComponentType, bool) {
+	componentTypeLock.RLock()
+	defer componentTypeLock.RUnlock()
+
+	ct, ok := componentTypesByName[name]
+	return ct, ok
+}
+
 type ComponentType uint8
 
+// Name returns the name this ComponentType was registered with, or the value of
+// String if this ComponentType was registered without a name.
+func (c ComponentType) Name() string {
+	componentTypeLock.RLock()
+	name := componentTypeNames[c]
+	componentTypeLock.RUnlock()
+
+	if name != "" {
+		return name
+	}
+
+	return c.String()
+}
+
 func (c ComponentType) String() string {
 	return "ct-" + strconv.FormatUint(uint64(c), 16)
 }
@@ -0,0 +1,64 @@
+package fecs_test
+
+import "github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+
+// Component types shared by the tests in this package.
+var (
+	positionType = fecs.NewNamedComponentType("Position")
+	velocityType = fecs.NewNamedComponentType("Velocity")
+	healthType   = fecs.NewNamedComponentType("Health")
+	frozenType   = fecs.NewNamedComponentType("Frozen")
+)
+
+func init() {
+	fecs.RegisterComponentSchema(positionType, fecs.ComponentSchema{Version: 1, New: func() fecs.Component { return new(Position) }})
+	fecs.RegisterComponentSchema(velocityType, fecs.ComponentSchema{Version: 1, New: func() fecs.Component { return new(Velocity) }})
+	fecs.RegisterComponentSchema(healthType, fecs.ComponentSchema{Version: 1, New: func() fecs.Component { return new(Health) }})
+	fecs.RegisterComponentSchema(frozenType, fecs.ComponentSchema{Version: 1, New: func() fecs.Component { return new(Frozen) }})
+}
+
+type Position struct {
+	X, Y float64
+}
+
+func (*Position) Type() fecs.ComponentType {
+	return positionType
+}
+
+type Velocity struct {
+	X, Y float64
+}
+
+func (*Velocity) Type() fecs.ComponentType {
+	return velocityType
+}
+
+type Health struct {
+	Points int
+}
+
+func (*Health) Type() fecs.ComponentType {
+	return healthType
+}
+
+type Frozen struct{}
+
+func (*Frozen) Type() fecs.ComponentType {
+	return frozenType
+}
+
+func newPosition(x, y float64) fecs.ComponentConstructor {
+	return func() fecs.Component { return &Position{x, y} }
+}
+
+func newVelocity(x, y float64) fecs.ComponentConstructor {
+	return func() fecs.Component { return &Velocity{x, y} }
+}
+
+func newHealth(points int) fecs.ComponentConstructor {
+	return func() fecs.Component { return &Health{points} }
+}
+
+func newFrozen() fecs.Component {
+	return &Frozen{}
+}
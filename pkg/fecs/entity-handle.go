@@ -0,0 +1,143 @@
+package fecs
+
+import "fmt"
+
+// Spawn creates a new entity in the given Scene and returns a handle to it.
+func Spawn(scene Scene) Entity {
+	return Entity{scene, scene.NewEntity()}
+}
+
+// HandleOf returns a handle to the entity identified by the given EntityID in
+// the given Scene.
+//
+// The target entity does not need to be alive; IsAlive may be used to test
+// whether it is.
+func HandleOf(scene Scene, id EntityID) Entity {
+	return Entity{scene, id}
+}
+
+// Entity is a handle pairing an EntityID with the Scene it belongs to.
+//
+// Every method call validates the EntityID, including its version, against the
+// Scene, so a handle to an entity that has been destroyed will never operate on
+// an unrelated entity that has since reused the same slot.
+//
+// The zero value is not a usable handle.
+type Entity struct {
+	scene Scene
+	id    EntityID
+}
+
+// ID returns the EntityID of the entity this handle refers to.
+func (e Entity) ID() EntityID {
+	return e.id
+}
+
+// Scene returns the Scene the entity this handle refers to belongs to.
+func (e Entity) Scene() Scene {
+	return e.scene
+}
+
+// IsAlive tests whether the entity this handle refers to still exists in its
+// Scene.
+func (e Entity) IsAlive() bool {
+	return e.scene.ContainsEntity(&e.id)
+}
+
+// Add attaches a new Component created by the given constructor to the entity.
+//
+// Returns an error wrapping ErrWrongScene if the handle's EntityID was created
+// by a different Scene, ErrEntityNotFound if the entity no longer exists, or
+// ErrDuplicateComponent if the entity already has a Component of the same
+// ComponentType attached.
+func (e Entity) Add(constructor ComponentConstructor) (ComponentID, error) {
+	return e.scene.TryAttachComponent(&e.id, constructor)
+}
+
+// Remove detaches the Component of the given ComponentType from the entity.
+//
+// Returns an error wrapping ErrWrongScene if the handle's EntityID was created
+// by a different Scene, ErrEntityNotFound if the entity no longer exists, or
+// ErrComponentNotFound if the entity has no Component of the given
+// ComponentType attached.
+func (e Entity) Remove(ct ComponentType) error {
+	cid, err := e.componentID(ct)
+	if err != nil {
+		return err
+	}
+
+	return e.scene.TryRemoveComponent(&e.id, &cid)
+}
+
+// Get returns the Component of the given ComponentType attached to the entity.
+//
+// If the entity no longer exists or has no Component of the given
+// ComponentType attached, this method returns nil and false.
+func (e Entity) Get(ct ComponentType) (Component, bool) {
+	comp, err := e.scene.TryGetComponentByType(&e.id, ct)
+	return comp, err == nil
+}
+
+// Has tests whether the entity has a Component of the given ComponentType
+// attached.
+//
+// If the entity no longer exists, this method returns false.
+func (e Entity) Has(ct ComponentType) bool {
+	_, err := e.scene.TryGetComponentByType(&e.id, ct)
+	return err == nil
+}
+
+// Components returns the ComponentIDs of all the Components attached to the
+// entity.
+//
+// If the entity no longer exists, this method returns nil.
+func (e Entity) Components() []ComponentID {
+	return e.scene.Components(&e.id)
+}
+
+// Destroy removes the entity from its Scene along with all of its Components.
+//
+// Returns an error wrapping ErrWrongScene if the handle's EntityID was created
+// by a different Scene, or ErrEntityNotFound if the entity no longer exists.
+func (e Entity) Destroy() error {
+	return e.scene.TryDestroyEntity(&e.id)
+}
+
+func (e Entity) String() string {
+	return e.id.String()
+}
+
+func (e Entity) componentID(ct ComponentType) (ComponentID, error) {
+	if impl, ok := e.scene.(*scene); ok {
+		if err := impl.checkEntity(&e.id); err != nil {
+			return ComponentID{}, err
+		}
+	} else if !e.scene.ContainsEntity(&e.id) {
+		return ComponentID{}, fmt.Errorf("entity %s is not in scene %s: %w", e.id.String(), e.scene.String(), ErrEntityNotFound)
+	}
+
+	cids := e.scene.Components(&e.id)
+
+	for i := range cids {
+		if cids[i].ctype == ct {
+			return cids[i], nil
+		}
+	}
+
+	return ComponentID{}, fmt.Errorf("component of type %s on entity %s: %w", ct.String(), e.id.String(), ErrComponentNotFound)
+}
+
+// GetAs returns the Component of the given ComponentType attached to the entity
+// referred to by the given handle, asserted to type T.
+//
+// If the entity no longer exists or has no Component of the given
+// ComponentType attached, this function returns the zero value of T and false.
+// If the Component is not of type T, this function will panic.
+func GetAs[T Component](e Entity, ct ComponentType) (T, bool) {
+	if comp, ok := e.Get(ct); ok {
+		return comp.(T), true
+	}
+
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,74 @@
+package fecs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func TestEntityHandleErrors(t *testing.T) {
+	scene, other := fecs.NewScene(), fecs.NewScene()
+
+	live := fecs.Spawn(scene)
+	_, err := live.Add(newPosition(1, 2))
+	mustNoErr(t, err)
+
+	dead := fecs.Spawn(scene)
+	_, err = dead.Add(newPosition(0, 0))
+	mustNoErr(t, err)
+	mustNoErr(t, dead.Destroy())
+
+	// The foreign entity occupies the same slot in its own scene as live does in
+	// scene, and has the same component attached.
+	elsewhere := fecs.Spawn(other)
+	_, err = elsewhere.Add(newPosition(3, 4))
+	mustNoErr(t, err)
+	foreign := fecs.HandleOf(scene, elsewhere.ID())
+
+	remove := func(ct fecs.ComponentType) func(fecs.Entity) error {
+		return func(e fecs.Entity) error { return e.Remove(ct) }
+	}
+
+	add := func(e fecs.Entity) error {
+		_, err := e.Add(newPosition(0, 0))
+		return err
+	}
+
+	destroy := func(e fecs.Entity) error { return e.Destroy() }
+
+	tests := []struct {
+		name   string
+		handle fecs.Entity
+		op     func(fecs.Entity) error
+		want   error
+	}{
+		{"remove from a foreign entity", foreign, remove(positionType), fecs.ErrWrongScene},
+		{"remove a missing type from a foreign entity", foreign, remove(velocityType), fecs.ErrWrongScene},
+		{"add to a foreign entity", foreign, add, fecs.ErrWrongScene},
+		{"destroy a foreign entity", foreign, destroy, fecs.ErrWrongScene},
+		{"remove from a destroyed entity", dead, remove(positionType), fecs.ErrEntityNotFound},
+		{"add to a destroyed entity", dead, add, fecs.ErrEntityNotFound},
+		{"destroy a destroyed entity", dead, destroy, fecs.ErrEntityNotFound},
+		{"remove a missing type", live, remove(velocityType), fecs.ErrComponentNotFound},
+		{"add a duplicate type", live, add, fecs.ErrDuplicateComponent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := snapshotOf(t, scene)
+
+			if err := test.op(test.handle); !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+
+			if snapshotOf(t, scene) != before {
+				t.Errorf("failed operation changed the scene")
+			}
+		})
+	}
+
+	if !elsewhere.Has(positionType) || !live.Has(positionType) {
+		t.Errorf("a failed operation removed a component")
+	}
+}
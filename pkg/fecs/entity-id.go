@@ -1,12 +1,17 @@
 package fecs
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"regexp"
 
 	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
 )
 
+// entityIDBinarySize is the length of the binary form of an EntityID.
+const entityIDBinarySize = 12
+
 var entityIdRegex = regexp.MustCompile(`^eid-([0-9a-fA-F]{1,8})-([0-9a-fA-F]{1,8})-([0-9a-fA-F]{1,8})$`)
 
 // ParseEntityID parses the given stringified EntityID value into a real
@@ -22,11 +27,22 @@ func ParseEntityID(idString string) (EntityID, error) {
 		return EntityID{}, fmt.Errorf("invalid entity id string: %s", idString)
 	}
 
-	return EntityID{
-		scene:   futil.MustParseHexUint32(matches[1]),
-		index:   futil.MustParseHexUint32(matches[2]),
-		version: futil.MustParseHexUint32(matches[3]),
-	}, nil
+	var out EntityID
+	var err error
+
+	if out.scene, err = futil.ParseHexUint32(matches[1]); err != nil {
+		return EntityID{}, fmt.Errorf("invalid entity id string: %s: %w", idString, err)
+	}
+
+	if out.index, err = futil.ParseHexUint32(matches[2]); err != nil {
+		return EntityID{}, fmt.Errorf("invalid entity id string: %s: %w", idString, err)
+	}
+
+	if out.version, err = futil.ParseHexUint32(matches[3]); err != nil {
+		return EntityID{}, fmt.Errorf("invalid entity id string: %s: %w", idString, err)
+	}
+
+	return out, nil
 }
 
 type EntityID struct {
@@ -82,3 +98,84 @@ func (e *EntityID) Equals(other *EntityID) bool {
 func (e *EntityID) String() string {
 	return fmt.Sprintf("eid-%x-%x-%x", e.scene, e.index, e.version)
 }
+
+// MarshalText implements encoding.TextMarshaler, producing the same form as
+// String.
+func (e EntityID) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the form parsed
+// by ParseEntityID.
+func (e *EntityID) UnmarshalText(text []byte) error {
+	id, err := ParseEntityID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*e = id
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, producing the String form of this
+// EntityID as a JSON string.
+func (e EntityID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + e.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a JSON string in the
+// form parsed by ParseEntityID.
+//
+// A JSON null leaves this EntityID unchanged.
+func (e *EntityID) UnmarshalJSON(data []byte) error {
+	text, err := unquoteID(data, "entity")
+	if err != nil || text == nil {
+		return err
+	}
+
+	return e.UnmarshalText(text)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The binary form of an EntityID is 12 bytes long, holding the scene, index and
+// version values of the EntityID in that order, each as a big endian uint32.
+func (e EntityID) MarshalBinary() ([]byte, error) {
+	out := make([]byte, entityIDBinarySize)
+
+	binary.BigEndian.PutUint32(out[0:], e.scene)
+	binary.BigEndian.PutUint32(out[4:], e.index)
+	binary.BigEndian.PutUint32(out[8:], e.version)
+
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, accepting the form
+// produced by MarshalBinary.
+func (e *EntityID) UnmarshalBinary(data []byte) error {
+	if len(data) != entityIDBinarySize {
+		return fmt.Errorf("invalid entity id binary length %d, expected %d", len(data), entityIDBinarySize)
+	}
+
+	e.scene = binary.BigEndian.Uint32(data[0:])
+	e.index = binary.BigEndian.Uint32(data[4:])
+	e.version = binary.BigEndian.Uint32(data[8:])
+
+	return nil
+}
+
+// unquoteID returns the contents of the given JSON string holding the
+// stringified form of an ID of the given kind, or nil if the given JSON value is
+// null.
+func unquoteID(data []byte, kind string) ([]byte, error) {
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return nil, fmt.Errorf("invalid %s id json value %s: expected a string", kind, data)
+	}
+
+	return []byte(text), nil
+}
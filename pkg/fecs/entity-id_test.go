@@ -0,0 +1,160 @@
+package fecs_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+// idMarshaler is the set of marshaling methods shared by EntityID and
+// ComponentID.
+type idMarshaler interface {
+	comparable
+	encoding.TextMarshaler
+	encoding.BinaryMarshaler
+	json.Marshaler
+}
+
+type idUnmarshaler[T any] interface {
+	*T
+	encoding.TextUnmarshaler
+	encoding.BinaryUnmarshaler
+	json.Unmarshaler
+}
+
+// testIDRoundTrip tests that the given ID survives its text, JSON and binary
+// forms, and that its text form matches the given string.
+func testIDRoundTrip[T idMarshaler, PT idUnmarshaler[T]](t *testing.T, id T, text string) {
+	t.Helper()
+
+	if got, _ := id.MarshalText(); string(got) != text {
+		t.Errorf("MarshalText() = %q, want %q", got, text)
+	}
+
+	var fromText T
+	if err := PT(&fromText).UnmarshalText([]byte(text)); err != nil || fromText != id {
+		t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, fromText, err, id)
+	}
+
+	js, _ := id.MarshalJSON()
+	if want := `"` + text + `"`; string(js) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", js, want)
+	}
+
+	var fromJSON T
+	if err := PT(&fromJSON).UnmarshalJSON(js); err != nil || fromJSON != id {
+		t.Errorf("UnmarshalJSON(%s) = %v, %v, want %v", js, fromJSON, err, id)
+	}
+
+	// A JSON null leaves the ID unchanged.
+	kept := id
+	if err := PT(&kept).UnmarshalJSON([]byte("null")); err != nil || kept != id {
+		t.Errorf("UnmarshalJSON(null) = %v, %v, want %v unchanged", kept, err, id)
+	}
+
+	bin, _ := id.MarshalBinary()
+	if len(bin) != 12 {
+		t.Errorf("MarshalBinary() is %d bytes long, want 12", len(bin))
+	}
+
+	var fromBinary T
+	if err := PT(&fromBinary).UnmarshalBinary(bin); err != nil || fromBinary != id {
+		t.Errorf("UnmarshalBinary(%x) = %v, %v, want %v", bin, fromBinary, err, id)
+	}
+}
+
+// testIDRejects tests that every given malformed form returns an error without
+// panicking and leaves the target ID unchanged.
+func testIDRejects[T comparable, PT idUnmarshaler[T]](t *testing.T, texts, jsons []string, binaries [][]byte) {
+	t.Helper()
+
+	var zero T
+
+	for _, text := range texts {
+		var id T
+		if err := PT(&id).UnmarshalText([]byte(text)); err == nil || id != zero {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want an error", text, id, err)
+		}
+	}
+
+	for _, js := range jsons {
+		var id T
+		if err := PT(&id).UnmarshalJSON([]byte(js)); err == nil || id != zero {
+			t.Errorf("UnmarshalJSON(%s) = %v, %v, want an error", js, id, err)
+		}
+	}
+
+	for _, bin := range binaries {
+		var id T
+		if err := PT(&id).UnmarshalBinary(bin); err == nil || id != zero {
+			t.Errorf("UnmarshalBinary(%x) = %v, %v, want an error", bin, id, err)
+		}
+	}
+}
+
+func TestEntityIDMarshaling(t *testing.T) {
+	scene := fecs.NewScene()
+	scene.NewEntity()
+	id := scene.NewEntity()
+
+	testIDRoundTrip(t, id, id.String())
+
+	largest, err := fecs.ParseEntityID("eid-ffffffff-ffffffff-ffffffff")
+	mustNoErr(t, err)
+	testIDRoundTrip(t, largest, "eid-ffffffff-ffffffff-ffffffff")
+
+	if upper, err := fecs.ParseEntityID("eid-FFFFFFFF-FFFFFFFF-FFFFFFFF"); err != nil || upper != largest {
+		t.Errorf("ParseEntityID() of upper case hex = %v, %v, want %v", upper, err, largest)
+	}
+
+	testIDRejects[fecs.EntityID](t,
+		[]string{"", "cid-1-2-3", "eid-1-2", "eid-1-2-3-4", "eid-1-2-xyz", "eid-123456789-2-3", "eid--2-3", " eid-1-2-3"},
+		[]string{`1`, `{}`, `"eid-1-2"`, `"eid`, `["eid-1-2-3"]`},
+		[][]byte{nil, make([]byte, 11), make([]byte, 13)},
+	)
+}
+
+func TestIDsInJSON(t *testing.T) {
+	scene := fecs.NewScene()
+	eid := scene.NewEntity()
+	cid := scene.AttachComponent(&eid, newPosition(0, 0))
+
+	type record struct {
+		Entity    fecs.EntityID            `json:"entity"`
+		Component fecs.ComponentID         `json:"component"`
+		Optional  *fecs.EntityID           `json:"optional"`
+		Targets   []fecs.EntityID          `json:"targets"`
+		ByEntity  map[fecs.EntityID]string `json:"byEntity"`
+		Owners    map[fecs.ComponentID]int `json:"owners"`
+	}
+
+	in := record{
+		Entity:    eid,
+		Component: cid,
+		Targets:   []fecs.EntityID{eid, eid},
+		ByEntity:  map[fecs.EntityID]string{eid: "player"},
+		Owners:    map[fecs.ComponentID]int{cid: 1},
+	}
+
+	data, err := json.Marshal(in)
+	mustNoErr(t, err)
+
+	if !bytes.Contains(data, []byte(`"entity":"`+eid.String()+`"`)) || !bytes.Contains(data, []byte(`"optional":null`)) {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var out record
+	mustNoErr(t, json.Unmarshal(data, &out))
+
+	if out.Entity != eid || out.Component != cid || out.Optional != nil || len(out.Targets) != 2 || out.Targets[1] != eid ||
+		out.ByEntity[eid] != "player" || out.Owners[cid] != 1 {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", out, in)
+	}
+
+	if err = json.Unmarshal([]byte(`{"entity":"eid-zz-1-1"}`), &out); err == nil {
+		t.Errorf("json.Unmarshal() accepted a malformed entity id")
+	}
+}
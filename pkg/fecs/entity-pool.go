@@ -4,9 +4,12 @@ import (
 	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
 )
 
+const entityPoolInitialCapacity = 32
+
 func newEntityPool() (out entityPool) {
 	out.free = futil.NewStack[uint32]()
-	out.pool = make([]entity, 32)
+	out.pool = make([]entity, entityPoolInitialCapacity)
+	out.allocs = 1
 	return
 }
 
@@ -14,6 +17,14 @@ type entityPool struct {
 	free futil.Stack[uint32]
 	pool []entity
 	size uint32
+
+	// allocs counts the number of times the pool slice has been allocated.
+	allocs uint32
+
+	// floor is the highest version that was in use by any slot discarded by
+	// compaction.  Slots appended to the pool start from this version so that
+	// EntityIDs issued before compaction are never reissued.
+	floor uint32
 }
 
 func (e *entityPool) addComponent(eid *EntityID, cid *ComponentID) {
@@ -24,6 +35,17 @@ func (e *entityPool) addComponent(eid *EntityID, cid *ComponentID) {
 	e.pool[eid.index].addComponent(cid)
 }
 
+// removeComponent unlinks the given ComponentID from the entity identified by
+// the given EntityID, returning a boolean value that indicates whether the
+// target component was attached to the target entity to begin with.
+func (e *entityPool) removeComponent(eid *EntityID, cid *ComponentID) bool {
+	if !e.containsEntity(eid) {
+		return false
+	}
+
+	return e.pool[eid.index].removeComponent(cid)
+}
+
 // containsEntity tests whether this entityPool contains an entity matching the
 // given EntityID.
 func (e *entityPool) containsEntity(id *EntityID) bool {
@@ -58,6 +80,13 @@ func (e *entityPool) entities(ct []ComponentType) futil.Iterator[EntityID] {
 	return futil.NewMappingIterator[*entity, EntityID](filtered, _entityIteratorMapper)
 }
 
+// query returns an Iterator over the EntityIDs of the entities contained in
+// this entityPool whose component masks satisfy the given Query.
+func (e *entityPool) query(q *Query) futil.Iterator[EntityID] {
+	filtered := futil.NewFilteredIterator[*entity](&entityIterator{pool: e.pool}, func(e *entity) bool { return q.matches(&e.mask) })
+	return futil.NewMappingIterator[*entity, EntityID](filtered, _entityIteratorMapper)
+}
+
 // entityCount returns the number of entities currently in this entityPool.
 func (e *entityPool) entityCount() uint32 {
 	return e.size - uint32(e.free.Size())
@@ -108,9 +137,17 @@ func (e *entityPool) newEntity(id SceneID) EntityID {
 	}
 }
 
+// reserve ensures that this entityPool has room for at least n new entities
+// without needing to grow its pool slice.
+func (e *entityPool) reserve(n uint32) {
+	if free := uint32(e.free.Size()); n > free {
+		e._ensureCapacity(e.size + n - free)
+	}
+}
+
 // removeEntity removes the entity identified by the given EntityID from this
 // entityPool, returning a boolean value that indicates whether the target
-// entity was in this entityPool to begin with.-
+// entity was in this entityPool to begin with.
 func (e *entityPool) removeEntity(id *EntityID) bool {
 	if !e.containsEntity(id) {
 		return false
@@ -122,8 +159,81 @@ func (e *entityPool) removeEntity(id *EntityID) bool {
 	return true
 }
 
+// restoreEntity brings back the entity identified by the given EntityID,
+// reusing exactly the given index and version values rather than generating
+// new ones.
+//
+// The target slot must either be a dead slot in this entityPool or the next
+// slot past the end of the pool.  If the target slot is currently occupied by
+// a living entity, or lies beyond the end of the pool, this method returns
+// false.
+func (e *entityPool) restoreEntity(id *EntityID) bool {
+	if id.index > e.size {
+		return false
+	}
+
+	if id.index == e.size {
+		e._ensureCapacity(e.size + 1)
+		e.size++
+	} else if e.pool[id.index].isLiving(id.index) {
+		return false
+	} else {
+		removeFromStack(e.free, id.index)
+	}
+
+	e.pool[id.index].id = *id
+
+	return true
+}
+
+// compact packs the living entities in this entityPool into the front of the
+// pool, preserving their order, and shrinks the pool slice to fit.
+//
+// Every entity that is moved is given a new EntityID, with a version greater
+// than any version previously used in its new slot.  The old and new EntityIDs
+// of each moved entity are recorded in the given map.
+func (e *entityPool) compact(moved map[EntityID]EntityID) {
+	live := uint32(0)
+
+	for i := uint32(0); i < e.size; i++ {
+		if !e.pool[i].isLiving(i) {
+			continue
+		}
+
+		if i != live {
+			// The target slot holds either a dead entity or an entity that has
+			// already been moved further down, either way its version is the last
+			// one that was used in that slot.
+			version := e.pool[live].id.version + 1
+			old := e.pool[i].id
+
+			e.pool[live] = e.pool[i]
+			e.pool[live].id.index = live
+			e.pool[live].id.version = version
+
+			moved[old] = e.pool[live].id
+		}
+
+		live++
+	}
+
+	for i := live; i < e.size; i++ {
+		e.floor = max(e.floor, e.pool[i].id.version)
+	}
+
+	tmp := make([]entity, max(live, entityPoolInitialCapacity))
+	copy(tmp, e.pool[:live])
+	e.pool = tmp
+	e.allocs++
+
+	e.size = live
+	e.free = futil.NewStack[uint32]()
+}
+
 // _append adds a new entity to the end of the entityPool.
 func (e *entityPool) _append(id SceneID) EntityID {
+	e._ensureCapacity(e.size + 1)
+	e.pool[e.size].id.version = e.floor
 	e.pool[e.size].birth(id, e.size)
 	e.size++
 
@@ -145,7 +255,7 @@ func (e *entityPool) _overwrite() EntityID {
 // If the current capacity is less than the given minimum value, this method
 // will replace the pool slice with a new, larger pool slice.
 func (e *entityPool) _ensureCapacity(minimum uint32) {
-	if minimum < uint32(len(e.pool)) {
+	if minimum <= uint32(len(e.pool)) {
 		return
 	}
 
@@ -154,6 +264,7 @@ func (e *entityPool) _ensureCapacity(minimum uint32) {
 	tmp := make([]entity, newSize)
 	copy(tmp, e.pool)
 	e.pool = tmp
+	e.allocs++
 }
 
 // _entityIteratorMapper maps a given entity value to it's EntityID.
@@ -164,3 +275,28 @@ func (e *entityPool) _ensureCapacity(minimum uint32) {
 func _entityIteratorMapper(e *entity) EntityID {
 	return e.id
 }
+
+// removeFromStack removes the given value from the given Stack, preserving the
+// order of the remaining values.
+//
+// Values are popped off of the stack until the target is found, so removing a
+// value from (or near) the top of the stack is cheap.
+func removeFromStack(stack futil.Stack[uint32], value uint32) bool {
+	popped := make([]uint32, 0, 8)
+	found := false
+
+	for !stack.IsEmpty() {
+		if v := stack.Pop(); v == value {
+			found = true
+			break
+		} else {
+			popped = append(popped, v)
+		}
+	}
+
+	for i := len(popped) - 1; i >= 0; i-- {
+		stack.Push(popped[i])
+	}
+
+	return found
+}
@@ -63,6 +63,10 @@ func (e *entity) removeComponent(id *ComponentID) bool {
 	}
 
 	copy(e.comps[idx:], e.comps[idx+1:])
+	e.comps[len(e.comps)-1] = nil
+	e.comps = e.comps[:len(e.comps)-1]
+
+	e.mask.remove(id.ctype)
 
 	return true
 }
@@ -0,0 +1,37 @@
+package fecs
+
+import "errors"
+
+var (
+	// ErrEntityNotFound is returned when a target entity does not exist in the
+	// Scene it was looked up in.
+	ErrEntityNotFound = errors.New("entity not found")
+
+	// ErrWrongScene is returned when an EntityID that was generated by one Scene
+	// is passed to a different Scene.
+	ErrWrongScene = errors.New("entity belongs to a different scene")
+
+	// ErrComponentNotFound is returned when a target Component does not exist in
+	// a Scene, or is not attached to the target entity.
+	ErrComponentNotFound = errors.New("component not found")
+
+	// ErrDuplicateComponent is returned when attempting to attach a Component to
+	// an entity that already has a Component of the same ComponentType.
+	ErrDuplicateComponent = errors.New("entity already has a component of the same type")
+
+	// ErrWrongComponentType is returned when a Component of one ComponentType is
+	// given where a Component of a different ComponentType was expected.
+	ErrWrongComponentType = errors.New("component has the wrong component type")
+
+	// ErrIllegalState is returned when the internal state of a Scene is found to
+	// be inconsistent.
+	ErrIllegalState = errors.New("illegal scene state")
+
+	// ErrNoComponentSchema is returned when loading a persisted Component whose
+	// ComponentType has no ComponentSchema registered.
+	ErrNoComponentSchema = errors.New("no component schema registered")
+
+	// ErrSchemaVersion is returned when loading a persisted Component that was
+	// written with a schema version newer than the registered ComponentSchema.
+	ErrSchemaVersion = errors.New("unsupported component schema version")
+)
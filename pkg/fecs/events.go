@@ -0,0 +1,246 @@
+package fecs
+
+import (
+	"iter"
+	"reflect"
+	"time"
+)
+
+// NewEventBus creates a new, empty EventBus for the given Scene.
+func NewEventBus(scene Scene) *EventBus {
+	return &EventBus{
+		scene:    scene,
+		channels: make(map[reflect.Type]eventChannel, 8),
+	}
+}
+
+// EventBus is a System that holds the typed event queues Systems of a Scene use
+// to communicate with each other.
+//
+// Events are published with Publish and consumed through an EventReader
+// created by NewEventReader.  Each event type has its own queue, see Events.
+//
+// Event queues are double buffered: each call to Update drops the events
+// published before the previous call to Update, so every event is readable
+// until the end of the tick after the one in which it was published.  This
+// means every System sees every event exactly once regardless of whether it
+// runs before or after the System that published the event, as long as each
+// System reads its events once per tick.
+//
+// The EventBus should be updated once per tick, typically as the last System
+// of a SystemGroup.
+//
+// EventBus is not safe for concurrent use.
+type EventBus struct {
+	scene    Scene
+	seq      uint64
+	channels map[reflect.Type]eventChannel
+}
+
+// Name implements System.Name.
+func (b *EventBus) Name() string {
+	return "events"
+}
+
+// Update advances every event queue on this EventBus by one tick, dropping the
+// events that were published before the previous call to Update.
+//
+// The given Scene and duration are ignored.
+func (b *EventBus) Update(Scene, time.Duration) {
+	for _, ch := range b.channels {
+		ch.update()
+	}
+}
+
+// Scene returns the Scene this EventBus belongs to.
+func (b *EventBus) Scene() Scene {
+	return b.scene
+}
+
+// Clear drops every event from every event queue on this EventBus.
+//
+// Events dropped by Clear that had not yet been read are counted as missed by
+// their EventReaders.
+func (b *EventBus) Clear() {
+	for _, ch := range b.channels {
+		ch.clear()
+	}
+}
+
+// EventsOf returns the queue for events of type T on the given EventBus,
+// creating it if it does not yet exist.
+func EventsOf[T any](bus *EventBus) *Events[T] {
+	key := reflect.TypeFor[T]()
+
+	if ch, ok := bus.channels[key]; ok {
+		return ch.(*Events[T])
+	}
+
+	out := &Events[T]{bus: bus}
+	bus.channels[key] = out
+
+	return out
+}
+
+// Publish adds the given event to the queue for events of type T on the given
+// EventBus.
+func Publish[T any](bus *EventBus, event T) {
+	EventsOf[T](bus).Send(event)
+}
+
+// NewEventReader creates a new EventReader for events of type T on the given
+// EventBus.
+func NewEventReader[T any](bus *EventBus) *EventReader[T] {
+	return EventsOf[T](bus).Reader()
+}
+
+// Events is the double buffered queue for events of a single type on an
+// EventBus.
+//
+// Events are always read in the order they were published.
+type Events[T any] struct {
+	bus *EventBus
+
+	// prev holds the events published during the previous tick, cur holds the
+	// events published during the current tick.
+	prev []eventRecord[T]
+	cur  []eventRecord[T]
+
+	// prevStart and curStart hold the absolute index of the first event in prev
+	// and cur respectively.
+	prevStart uint64
+	curStart  uint64
+}
+
+// Send publishes the given event.
+func (e *Events[T]) Send(event T) {
+	e.bus.seq++
+	e.cur = append(e.cur, eventRecord[T]{e.bus.seq, event})
+}
+
+// Len returns the number of events currently held in this queue.
+func (e *Events[T]) Len() int {
+	return len(e.prev) + len(e.cur)
+}
+
+// Reader creates a new EventReader for this queue.
+//
+// The new EventReader will read all the events currently held in this queue.
+func (e *Events[T]) Reader() *EventReader[T] {
+	return &EventReader[T]{events: e, cursor: e.prevStart}
+}
+
+func (e *Events[T]) update() {
+	clear(e.prev)
+
+	e.prev, e.cur = e.cur, e.prev[:0]
+	e.prevStart = e.curStart
+	e.curStart += uint64(len(e.prev))
+}
+
+func (e *Events[T]) clear() {
+	e.update()
+	e.update()
+}
+
+// end returns the absolute index one past the most recently published event.
+func (e *Events[T]) end() uint64 {
+	return e.curStart + uint64(len(e.cur))
+}
+
+// EventReader reads the events from an Events queue, keeping track of which
+// events it has already read independently of any other EventReader.
+type EventReader[T any] struct {
+	events *Events[T]
+
+	// cursor holds the absolute index of the next event to read.
+	cursor uint64
+	missed uint64
+}
+
+// Read returns an iter.Seq over the events that have been published since the
+// previous read and are still held by the queue, in the order they were
+// published.
+//
+// Each event is only yielded once by a given EventReader; if ranging over the
+// returned sequence is stopped early, the events that were not yet yielded are
+// returned by the next read.
+func (r *EventReader[T]) Read() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, event := range r.ReadOrdered() {
+			if !yield(event) {
+				return
+			}
+		}
+	}
+}
+
+// ReadOrdered is like Read, but also yields the sequence number each event was
+// published with.
+//
+// Sequence numbers are shared by every event type on an EventBus, so they may
+// be used to put events of different types back into the order they were
+// published in.
+func (r *EventReader[T]) ReadOrdered() iter.Seq2[uint64, T] {
+	return func(yield func(uint64, T) bool) {
+		r.skipDropped()
+
+		e := r.events
+
+		for ; r.cursor < e.end(); r.cursor++ {
+			var rec *eventRecord[T]
+
+			if r.cursor < e.curStart {
+				rec = &e.prev[r.cursor-e.prevStart]
+			} else {
+				rec = &e.cur[r.cursor-e.curStart]
+			}
+
+			if !yield(rec.seq, rec.value) {
+				r.cursor++
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of events that have not yet been read by this
+// EventReader and are still held by the queue.
+func (r *EventReader[T]) Len() int {
+	return int(r.events.end() - max(r.cursor, r.events.prevStart))
+}
+
+// Skip marks every event currently held by the queue as read.
+func (r *EventReader[T]) Skip() {
+	r.skipDropped()
+	r.cursor = r.events.end()
+}
+
+// Missed returns the number of events that were dropped from the queue before
+// this EventReader read them.
+//
+// Events are only missed by EventReaders that are not read at least once per
+// tick.
+func (r *EventReader[T]) Missed() uint64 {
+	r.skipDropped()
+	return r.missed
+}
+
+func (r *EventReader[T]) skipDropped() {
+	if r.cursor < r.events.prevStart {
+		r.missed += r.events.prevStart - r.cursor
+		r.cursor = r.events.prevStart
+	}
+}
+
+// eventChannel is the type independent interface the EventBus uses to manage
+// its Events queues.
+type eventChannel interface {
+	update()
+	clear()
+}
+
+type eventRecord[T any] struct {
+	seq   uint64
+	value T
+}
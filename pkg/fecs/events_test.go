@@ -0,0 +1,218 @@
+package fecs_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+type damaged struct{ amount int }
+
+type healed struct{ amount int }
+
+func readAll[T any](r *fecs.EventReader[T]) []T {
+	return slices.Collect(r.Read())
+}
+
+func TestEventsSurviveOneUpdate(t *testing.T) {
+	bus := fecs.NewEventBus(fecs.NewScene())
+	early := fecs.NewEventReader[damaged](bus)
+
+	fecs.Publish(bus, damaged{1})
+	bus.Update(nil, 0)
+
+	late := fecs.NewEventReader[damaged](bus)
+	stale := fecs.NewEventReader[damaged](bus)
+
+	if got := fecs.EventsOf[damaged](bus).Len(); got != 1 {
+		t.Errorf("Len() after one update = %d, want 1", got)
+	}
+
+	if got := stale.Len(); got != 1 {
+		t.Errorf("reader Len() = %d, want 1", got)
+	}
+
+	if got := readAll(early); !slices.Equal(got, []damaged{{1}}) {
+		t.Errorf("reader created before publishing read %v", got)
+	}
+
+	if got := readAll(late); !slices.Equal(got, []damaged{{1}}) {
+		t.Errorf("reader created after one update read %v", got)
+	}
+
+	bus.Update(nil, 0)
+
+	if got := fecs.EventsOf[damaged](bus).Len(); got != 0 {
+		t.Errorf("Len() after two updates = %d, want 0", got)
+	}
+
+	if got := readAll(fecs.NewEventReader[damaged](bus)); len(got) != 0 {
+		t.Errorf("reader created after two updates read %v", got)
+	}
+
+	if got := stale.Missed(); got != 1 {
+		t.Errorf("Missed() = %d, want 1", got)
+	}
+
+	if got := early.Missed(); got != 0 {
+		t.Errorf("Missed() of a reader that kept up = %d, want 0", got)
+	}
+}
+
+func TestEventsReadersAroundPublisher(t *testing.T) {
+	bus := fecs.NewEventBus(fecs.NewScene())
+	before := fecs.NewEventReader[damaged](bus)
+	after := fecs.NewEventReader[damaged](bus)
+
+	var seenBefore, seenAfter []damaged
+
+	// Each tick runs a reader, the publisher, and another reader, then updates
+	// the bus.
+	for tick := range 5 {
+		seenBefore = append(seenBefore, readAll(before)...)
+
+		for i := range tick {
+			fecs.Publish(bus, damaged{tick*10 + i})
+		}
+
+		seenAfter = append(seenAfter, readAll(after)...)
+		bus.Update(nil, 0)
+	}
+
+	seenBefore = append(seenBefore, readAll(before)...)
+
+	want := []damaged{{10}, {20}, {21}, {30}, {31}, {32}, {40}, {41}, {42}, {43}}
+
+	if !slices.Equal(seenBefore, want) {
+		t.Errorf("reader before the publisher saw %v, want %v", seenBefore, want)
+	}
+
+	if !slices.Equal(seenAfter, want) {
+		t.Errorf("reader after the publisher saw %v, want %v", seenAfter, want)
+	}
+
+	if before.Missed() != 0 || after.Missed() != 0 {
+		t.Errorf("readers missed %d and %d events, want 0", before.Missed(), after.Missed())
+	}
+}
+
+func TestEventsMissed(t *testing.T) {
+	tests := []struct {
+		name   string
+		read   int
+		drop   func(bus *fecs.EventBus)
+		missed uint64
+	}{
+		{"two updates without reading", 0, func(bus *fecs.EventBus) { bus.Update(nil, 0); bus.Update(nil, 0) }, 3},
+		{"two updates after a partial read", 1, func(bus *fecs.EventBus) { bus.Update(nil, 0); bus.Update(nil, 0) }, 2},
+		{"one update", 0, func(bus *fecs.EventBus) { bus.Update(nil, 0) }, 0},
+		{"clear", 0, func(bus *fecs.EventBus) { bus.Clear() }, 3},
+		{"clear after a partial read", 2, func(bus *fecs.EventBus) { bus.Clear() }, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := fecs.NewEventBus(fecs.NewScene())
+			reader := fecs.NewEventReader[damaged](bus)
+
+			for i := range 3 {
+				fecs.Publish(bus, damaged{i})
+			}
+
+			if test.read > 0 {
+				n := 0
+				for range reader.Read() {
+					if n++; n == test.read {
+						break
+					}
+				}
+			}
+
+			test.drop(bus)
+
+			if got := reader.Missed(); got != test.missed {
+				t.Errorf("Missed() = %d, want %d", got, test.missed)
+			}
+
+			if got, want := reader.Len(), 3-test.read-int(test.missed); got != want {
+				t.Errorf("Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestEventsReadResumesAfterBreak(t *testing.T) {
+	bus := fecs.NewEventBus(fecs.NewScene())
+	reader := fecs.NewEventReader[damaged](bus)
+
+	for i := range 4 {
+		fecs.Publish(bus, damaged{i})
+	}
+
+	for event := range reader.Read() {
+		if event.amount == 1 {
+			break
+		}
+	}
+
+	if got := reader.Len(); got != 2 {
+		t.Errorf("Len() after breaking = %d, want 2", got)
+	}
+
+	// A break straddling the update resumes in the new tick's buffer.
+	bus.Update(nil, 0)
+	fecs.Publish(bus, damaged{4})
+
+	for event := range reader.Read() {
+		if event.amount == 2 {
+			break
+		}
+	}
+
+	if got := readAll(reader); !slices.Equal(got, []damaged{{3}, {4}}) {
+		t.Errorf("resumed read = %v, want [{3} {4}]", got)
+	}
+
+	reader.Skip()
+	fecs.Publish(bus, damaged{5})
+	reader.Skip()
+
+	if got := readAll(reader); len(got) != 0 {
+		t.Errorf("read after Skip() = %v", got)
+	}
+}
+
+func TestEventsOrderAcrossTypes(t *testing.T) {
+	bus := fecs.NewEventBus(fecs.NewScene())
+	hits := fecs.NewEventReader[damaged](bus)
+	heals := fecs.NewEventReader[healed](bus)
+
+	fecs.Publish(bus, damaged{1})
+	fecs.Publish(bus, healed{2})
+	bus.Update(nil, 0)
+	fecs.Publish(bus, damaged{3})
+	fecs.Publish(bus, healed{4})
+	fecs.Publish(bus, damaged{5})
+
+	type entry struct {
+		seq    uint64
+		amount int
+	}
+
+	var log []entry
+	for seq, event := range hits.ReadOrdered() {
+		log = append(log, entry{seq, event.amount})
+	}
+	for seq, event := range heals.ReadOrdered() {
+		log = append(log, entry{seq, event.amount})
+	}
+
+	slices.SortFunc(log, func(a, b entry) int { return int(a.seq) - int(b.seq) })
+
+	for i, e := range log {
+		if e.amount != i+1 {
+			t.Fatalf("events in sequence order = %v, want amounts 1 to 5", log)
+		}
+	}
+}
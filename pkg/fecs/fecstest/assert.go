@@ -0,0 +1,155 @@
+package fecstest
+
+import (
+	"strings"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+// AssertHasComponent tests that the entity with the given EntityID exists in
+// the given Scene and has a Component of the given ComponentType attached.
+//
+// Marks the test as failed and returns false if it does not.
+func AssertHasComponent(t TB, scene fecs.Scene, id fecs.EntityID, ct fecs.ComponentType) bool {
+	t.Helper()
+
+	if !scene.ContainsEntity(&id) {
+		t.Errorf("expected entity %s to have a %s component, but it does not exist in scene %s", id.String(), ct.Name(), scene.String())
+		return false
+	}
+
+	if _, ok := scene.GetComponentByType(&id, ct); !ok {
+		t.Errorf("expected entity %s to have a %s component, but it has [%s]", id.String(), ct.Name(), componentNames(scene, id))
+		return false
+	}
+
+	return true
+}
+
+// AssertNoComponent tests that the entity with the given EntityID exists in the
+// given Scene and does not have a Component of the given ComponentType
+// attached.
+//
+// Marks the test as failed and returns false if it does not.
+func AssertNoComponent(t TB, scene fecs.Scene, id fecs.EntityID, ct fecs.ComponentType) bool {
+	t.Helper()
+
+	if !scene.ContainsEntity(&id) {
+		t.Errorf("expected entity %s to not have a %s component, but it does not exist in scene %s", id.String(), ct.Name(), scene.String())
+		return false
+	}
+
+	if _, ok := scene.GetComponentByType(&id, ct); ok {
+		t.Errorf("expected entity %s to not have a %s component, but it does", id.String(), ct.Name())
+		return false
+	}
+
+	return true
+}
+
+// AssertEntityCount tests that the given Scene contains exactly the given
+// number of entities having all the given ComponentTypes attached.  If no
+// ComponentTypes are given, every entity in the Scene is counted.
+//
+// Marks the test as failed and returns false if it does not.
+func AssertEntityCount(t TB, scene fecs.Scene, want int, componentTypes ...fecs.ComponentType) bool {
+	t.Helper()
+
+	got := 0
+	for range scene.EachEntity(componentTypes...) {
+		got++
+	}
+
+	if got != want {
+		if len(componentTypes) == 0 {
+			t.Errorf("expected scene %s to contain %d entities, got %d", scene.String(), want, got)
+		} else {
+			t.Errorf("expected scene %s to contain %d entities with [%s], got %d", scene.String(), want, typeNames(componentTypes), got)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// AssertQueryMatches tests that the given query expression matches exactly the
+// entities with the given EntityIDs in the given Scene, in any order.  See
+// fecs.ParseQuery.
+//
+// Fails the test immediately if the given expression is invalid.  Otherwise,
+// marks the test as failed and returns false if the matched entities differ
+// from the expected entities.
+func AssertQueryMatches(t TB, scene fecs.Scene, query string, want ...fecs.EntityID) bool {
+	t.Helper()
+
+	q, err := fecs.ParseQuery(query)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	expected := make(map[fecs.EntityID]bool, len(want))
+	for _, id := range want {
+		expected[id] = true
+	}
+
+	var unexpected []string
+
+	for id := range scene.EachQuery(q) {
+		if expected[id] {
+			delete(expected, id)
+		} else {
+			unexpected = append(unexpected, id.String())
+		}
+	}
+
+	if len(expected) == 0 && len(unexpected) == 0 {
+		return true
+	}
+
+	var msg strings.Builder
+	msg.WriteString("query ")
+	msg.WriteString(query)
+	msg.WriteString(" did not match the expected entities")
+
+	// Report the missing entities in the order they were given.
+	for _, id := range want {
+		if expected[id] {
+			msg.WriteString("\n\tmissing:    ")
+			msg.WriteString(id.String())
+			delete(expected, id)
+		}
+	}
+
+	for _, id := range unexpected {
+		msg.WriteString("\n\tunexpected: ")
+		msg.WriteString(id)
+	}
+
+	t.Errorf("%s", msg.String())
+
+	return false
+}
+
+// componentNames returns a comma separated list of the names of the
+// ComponentTypes attached to the entity with the given EntityID.
+func componentNames(scene fecs.Scene, id fecs.EntityID) string {
+	cids := scene.Components(&id)
+	types := make([]fecs.ComponentType, len(cids))
+
+	for i := range cids {
+		types[i] = cids[i].Type()
+	}
+
+	return typeNames(types)
+}
+
+func typeNames(types []fecs.ComponentType) string {
+	names := make([]string, len(types))
+
+	for i, ct := range types {
+		names[i] = ct.Name()
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,71 @@
+package fecstest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func TestAssertions(t *testing.T) {
+	scene, ids := NewScene(t, Fixture{
+		"player": {&Position{}, &Tag{}},
+		"wall":   {&Position{}},
+	})
+
+	gone := scene.NewEntity()
+	scene.DestroyEntity(&gone)
+
+	tests := []struct {
+		name  string
+		check func(t TB) bool
+		pass  bool
+		fatal bool
+		msg   string
+	}{
+		{"has component", func(t TB) bool { return AssertHasComponent(t, scene, ids["player"], tagType) }, true, false, ""},
+		{"missing component", func(t TB) bool { return AssertHasComponent(t, scene, ids["wall"], tagType) }, false, false, "it has [Position]"},
+		{"has component of dead entity", func(t TB) bool { return AssertHasComponent(t, scene, gone, tagType) }, false, false, "does not exist"},
+		{"no component", func(t TB) bool { return AssertNoComponent(t, scene, ids["wall"], tagType) }, true, false, ""},
+		{"unexpected component", func(t TB) bool { return AssertNoComponent(t, scene, ids["player"], tagType) }, false, false, "but it does"},
+		{"no component of dead entity", func(t TB) bool { return AssertNoComponent(t, scene, gone, tagType) }, false, false, "does not exist"},
+		{"entity count", func(t TB) bool { return AssertEntityCount(t, scene, 2) }, true, false, ""},
+		{"entity count by type", func(t TB) bool { return AssertEntityCount(t, scene, 1, tagType) }, true, false, ""},
+		{"wrong entity count", func(t TB) bool { return AssertEntityCount(t, scene, 3, positionType) }, false, false, "with [Position], got 2"},
+		{"query matches", func(t TB) bool { return AssertQueryMatches(t, scene, "Position & !Tag", ids["wall"]) }, true, false, ""},
+		{"query matches nothing", func(t TB) bool { return AssertQueryMatches(t, scene, "Target") }, true, false, ""},
+		{"query missing", func(t TB) bool {
+			return AssertQueryMatches(t, scene, "Tag", ids["player"], ids["wall"])
+		}, false, false, "missing:    " + idString(ids["wall"])},
+		{"query unexpected", func(t TB) bool {
+			return AssertQueryMatches(t, scene, "Position")
+		}, false, false, "unexpected: " + idString(ids["player"])},
+		{"invalid query", func(t TB) bool { return AssertQueryMatches(t, scene, "Position &") }, false, true, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var ok bool
+			rec := record(func(t TB) { ok = test.check(t) })
+
+			if test.fatal {
+				if rec.fatal == "" {
+					t.Errorf("expected the assertion to fail the test immediately")
+				}
+				return
+			}
+
+			if ok != test.pass || rec.failed() == test.pass {
+				t.Errorf("assertion returned %t with failures %q, want pass = %t", ok, rec.errors, test.pass)
+			}
+
+			if !test.pass && (len(rec.errors) != 1 || !strings.Contains(rec.errors[0], test.msg)) {
+				t.Errorf("failures = %q, want one containing %q", rec.errors, test.msg)
+			}
+		})
+	}
+}
+
+func idString(id fecs.EntityID) string {
+	return id.String()
+}
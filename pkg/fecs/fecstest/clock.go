@@ -0,0 +1,66 @@
+package fecstest
+
+import (
+	"sync"
+	"time"
+)
+
+// Epoch is the time a Clock created by NewClock starts at.
+var Epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NewClock creates a new Clock starting at Epoch.
+func NewClock() *Clock {
+	return &Clock{now: Epoch}
+}
+
+// Clock is a deterministic fecs.Clock whose time only changes when it is told
+// to, for driving time-dependent types such as fecs.Runner in tests.
+//
+//	clock := fecstest.NewClock()
+//	runner.SetClock(clock)
+//	clock.Advance(16 * time.Millisecond)
+//	runner.Advance()
+//
+// Clock is safe for concurrent use.
+type Clock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// Now implements fecs.Clock.Now.
+//
+// If an auto-advance step has been set with SetStep, the Clock is advanced by
+// that step after reading the current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := c.now
+	c.now = c.now.Add(c.step)
+
+	return out
+}
+
+// Advance moves this Clock forward by the given duration.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// Set moves this Clock to the given time.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
+
+// SetStep sets the duration this Clock advances by each time Now is called.
+//
+// A step of 0, the default, disables auto-advancing.
+func (c *Clock) SetStep(step time.Duration) {
+	c.mu.Lock()
+	c.step = step
+	c.mu.Unlock()
+}
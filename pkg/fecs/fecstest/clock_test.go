@@ -0,0 +1,46 @@
+package fecstest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	clock := NewClock()
+
+	if got := clock.Now(); !got.Equal(Epoch) {
+		t.Errorf("new clock reads %s, want %s", got, Epoch)
+	}
+
+	if got := clock.Now(); !got.Equal(Epoch) {
+		t.Errorf("clock without a step moved to %s", got)
+	}
+
+	clock.Advance(time.Second)
+
+	if got, want := clock.Now(), Epoch.Add(time.Second); !got.Equal(want) {
+		t.Errorf("after Advance clock reads %s, want %s", got, want)
+	}
+
+	later := Epoch.Add(time.Hour)
+	clock.Set(later)
+
+	if got := clock.Now(); !got.Equal(later) {
+		t.Errorf("after Set clock reads %s, want %s", got, later)
+	}
+
+	clock.SetStep(10 * time.Millisecond)
+
+	for i := range 3 {
+		if got, want := clock.Now(), later.Add(time.Duration(i)*10*time.Millisecond); !got.Equal(want) {
+			t.Errorf("stepped read %d = %s, want %s", i, got, want)
+		}
+	}
+
+	clock.SetStep(0)
+	clock.Now()
+
+	if got, want := clock.Now(), later.Add(30*time.Millisecond); !got.Equal(want) {
+		t.Errorf("after clearing the step clock reads %s, want %s", got, want)
+	}
+}
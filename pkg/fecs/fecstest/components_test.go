@@ -0,0 +1,89 @@
+package fecstest
+
+import (
+	"fmt"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+// Component types shared by the tests in this package.
+var (
+	positionType = fecs.NewNamedComponentType("Position")
+	targetType   = fecs.NewNamedComponentType("Target")
+	tagType      = fecs.NewNamedComponentType("Tag")
+)
+
+func init() {
+	fecs.RegisterComponentSchema(positionType, fecs.ComponentSchema{Version: 1, New: func() fecs.Component { return new(Position) }})
+	fecs.RegisterComponentSchema(targetType, fecs.ComponentSchema{Version: 1, New: func() fecs.Component { return new(Target) }})
+	fecs.RegisterComponentSchema(tagType, fecs.ComponentSchema{Version: 1, New: func() fecs.Component { return new(Tag) }})
+}
+
+type Position struct {
+	X, Y float64
+}
+
+func (*Position) Type() fecs.ComponentType {
+	return positionType
+}
+
+// Target references another entity, for testing the normalization of EntityIDs
+// held in Component values.
+type Target struct {
+	Of fecs.EntityID
+}
+
+func (*Target) Type() fecs.ComponentType {
+	return targetType
+}
+
+type Tag struct{}
+
+func (*Tag) Type() fecs.ComponentType {
+	return tagType
+}
+
+// recorder is a TB that records the failures reported to it instead of failing
+// the test.
+type recorder struct {
+	errors []string
+	fatal  string
+}
+
+// fatalStop is the value recorder.Fatalf panics with to stop the helper under
+// test, as testing.T.Fatalf would.
+type fatalStop struct{}
+
+// record runs the given function against a new recorder.
+func record(fn func(t TB)) *recorder {
+	out := new(recorder)
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(fatalStop); !ok {
+					panic(r)
+				}
+			}
+		}()
+
+		fn(out)
+	}()
+
+	return out
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.fatal = fmt.Sprintf(format, args...)
+	panic(fatalStop{})
+}
+
+func (r *recorder) failed() bool {
+	return len(r.errors) > 0 || r.fatal != ""
+}
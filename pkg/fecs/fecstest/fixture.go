@@ -0,0 +1,140 @@
+// Package fecstest provides utilities for testing code built on fecs Scenes.
+package fecstest
+
+import (
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+// TB is the subset of testing.TB used by the helpers in this package.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// Fixture declares the contents of a Scene.
+//
+// Each key of a Fixture names an entity, and the matching value lists the
+// Components to attach to that entity.
+//
+//	scene, ids := fecstest.NewScene(t, fecstest.Fixture{
+//		"player": {&Position{}, &Velocity{X: 1}},
+//		"wall":   {&Position{X: 10}},
+//	})
+//
+// Entities are created in name order, so building the same Fixture always
+// produces the same EntityIDs, ignoring the SceneID.  The Components of a
+// Fixture are copied as they are attached, so a single Fixture may be shared
+// between tests; see fecs.ComponentCloner.
+type Fixture map[string][]fecs.Component
+
+// Entities maps the entity names of a Fixture to the EntityIDs of the entities
+// built from it.
+type Entities map[string]fecs.EntityID
+
+// Name returns the name of the entity with the given EntityID, if it was built
+// from a Fixture.
+func (e Entities) Name(id fecs.EntityID) (string, bool) {
+	for name, eid := range e {
+		if eid == id {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// NewScene creates a new Scene containing the entities declared by the given
+// Fixture.
+//
+// Fails the test immediately if the Fixture cannot be built.  See Build.
+func NewScene(t TB, fixture Fixture) (fecs.Scene, Entities) {
+	t.Helper()
+
+	scene := fecs.NewScene()
+
+	return scene, fixture.Build(t, scene)
+}
+
+// Build creates the entities declared by this Fixture in the given Scene.
+//
+// Fails the test immediately if any entity of the Fixture lists more than one
+// Component of the same ComponentType.
+func (f Fixture) Build(t TB, scene fecs.Scene) Entities {
+	t.Helper()
+
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	out := make(Entities, len(f))
+
+	for _, name := range names {
+		id := scene.NewEntity()
+
+		for _, comp := range f[name] {
+			if _, err := scene.TryAttachComponent(&id, func() fecs.Component { return clone(comp) }); err != nil {
+				t.Fatalf("failed to build fixture entity %q: %s", name, err)
+			}
+		}
+
+		out[name] = id
+	}
+
+	return out
+}
+
+// LoadScene creates a new Scene containing the entities of the Snapshot file at
+// the given path, such as one written by AssertGolden.
+//
+// Returns the new Scene and a map of the EntityIDs recorded in the Snapshot to
+// the EntityIDs of the loaded entities.  Fails the test immediately if the
+// Snapshot cannot be read or any of its entities cannot be loaded.
+func LoadScene(t TB, path string) (fecs.Scene, map[fecs.EntityID]fecs.EntityID) {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open scene snapshot: %s", err)
+	}
+	defer file.Close()
+
+	snap, err := fecs.ReadSnapshot(file)
+	if err != nil {
+		t.Fatalf("failed to load %s: %s", path, err)
+	}
+
+	scene := fecs.NewScene()
+
+	ids, err := fecs.LoadSnapshot(scene, snap)
+	if err != nil {
+		t.Fatalf("failed to load %s: %s", path, err)
+	}
+
+	return scene, ids
+}
+
+// clone returns a copy of the given Component, following the same rules as
+// fecs.SceneState.
+func clone(comp fecs.Component) fecs.Component {
+	if cloner, ok := comp.(fecs.ComponentCloner); ok {
+		return cloner.Clone()
+	}
+
+	value := reflect.ValueOf(comp)
+
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		out := reflect.New(value.Elem().Type())
+		out.Elem().Set(value.Elem())
+		return out.Interface().(fecs.Component)
+	}
+
+	return comp
+}
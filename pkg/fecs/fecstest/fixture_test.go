@@ -0,0 +1,84 @@
+package fecstest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func TestFixtureBuild(t *testing.T) {
+	fixture := Fixture{
+		"wall":   {&Position{X: 10}},
+		"player": {&Position{X: 1, Y: 2}, &Tag{}},
+		"camera": {},
+	}
+
+	scene, ids := NewScene(t, fixture)
+
+	var order []string
+	for it := scene.Entities(); it.HasNext(); {
+		name, ok := ids.Name(it.Next())
+		if !ok {
+			t.Fatalf("scene contains an entity not built from the fixture")
+		}
+
+		order = append(order, name)
+	}
+
+	if got, want := strings.Join(order, ","), "camera,player,wall"; got != want {
+		t.Errorf("entities built in order %s, want %s", got, want)
+	}
+
+	AssertEntityCount(t, scene, 3)
+	AssertEntityCount(t, scene, 2, positionType)
+	AssertEntityCount(t, scene, 1, positionType, tagType)
+
+	if _, ok := ids.Name(fecs.NewScene().NewEntity()); ok {
+		t.Errorf("Name found an entity that was not built from the fixture")
+	}
+
+	// Building the same fixture again yields the same IDs, ignoring the scene.
+	_, again := NewScene(t, fixture)
+
+	for name, id := range ids {
+		want := id
+		got := again[name]
+
+		if strings.SplitN(got.String(), "-", 3)[2] != strings.SplitN(want.String(), "-", 3)[2] {
+			t.Errorf("entity %q was built as %v, want %v in another scene", name, got, want)
+		}
+	}
+}
+
+func TestFixtureBuildClonesComponents(t *testing.T) {
+	fixture := Fixture{"player": {&Position{X: 1, Y: 2}}}
+
+	first, firstIDs := NewScene(t, fixture)
+	player := firstIDs["player"]
+
+	comp, _ := first.GetComponentByType(&player, positionType)
+	comp.(*Position).X = 99
+
+	if got := fixture["player"][0].(*Position).X; got != 1 {
+		t.Errorf("mutating a built component changed the fixture to X = %v", got)
+	}
+
+	second, secondIDs := NewScene(t, fixture)
+	player = secondIDs["player"]
+
+	comp, _ = second.GetComponentByType(&player, positionType)
+	if got := comp.(*Position).X; got != 1 {
+		t.Errorf("second build got X = %v, want 1", got)
+	}
+}
+
+func TestFixtureBuildDuplicateType(t *testing.T) {
+	rec := record(func(t TB) {
+		NewScene(t, Fixture{"player": {&Position{}, &Position{}}})
+	})
+
+	if !strings.Contains(rec.fatal, `"player"`) {
+		t.Errorf("fatal = %q, want a failure naming the entity", rec.fatal)
+	}
+}
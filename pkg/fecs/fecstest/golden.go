@@ -0,0 +1,124 @@
+package fecstest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+var update = flag.Bool("fecstest.update", false, "rewrite fecstest golden files instead of comparing against them")
+
+// AssertGolden tests that a Snapshot of the given Scene matches the Snapshot
+// stored in the golden file at the given path.
+//
+// Snapshots are normalized before being compared so that the result does not
+// depend on the SceneID of the given Scene: the recorded SceneID is cleared, and
+// every EntityID string belonging to the given Scene is rewritten as if it
+// belonged to Scene 0.  Golden files may be loaded back into a Scene with
+// LoadScene.
+//
+// When the test binary is run with the -fecstest.update flag, the golden file
+// is written instead of compared against:
+//
+//	go test ./... -fecstest.update
+//
+// Fails the test immediately if the Snapshot cannot be taken or the golden file
+// cannot be read or written.  Otherwise, marks the test as failed and returns
+// false if the Snapshot does not match the golden file.
+func AssertGolden(t TB, scene fecs.Scene, path string) bool {
+	t.Helper()
+
+	got, err := goldenSnapshot(scene)
+	if err != nil {
+		t.Fatalf("failed to take golden snapshot of scene %s: %s", scene.String(), err)
+	}
+
+	if *update {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to update golden file: %s", err)
+		}
+
+		if err = os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %s", err)
+		}
+
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			t.Fatalf("golden file %s does not exist, run the test with -fecstest.update to create it", path)
+		}
+
+		t.Fatalf("failed to read golden file: %s", err)
+	}
+
+	if line, ok := firstDifference(want, got); !ok {
+		t.Errorf("snapshot of scene %s does not match golden file %s:%d\n\twant: %s\n\tgot:  %s\n\trun the test with -fecstest.update to accept the new snapshot",
+			scene.String(), path, line.number, line.want, line.got)
+		return false
+	}
+
+	return true
+}
+
+// goldenSnapshot returns the normalized JSON form of a Snapshot of the given
+// Scene.
+func goldenSnapshot(scene fecs.Scene) ([]byte, error) {
+	snap, err := fecs.TakeSnapshot(scene)
+	if err != nil {
+		return nil, err
+	}
+
+	snap.Scene = 0
+
+	buf := new(bytes.Buffer)
+	if err = snap.Write(buf); err != nil {
+		return nil, err
+	}
+
+	// Rewriting the serialized form catches EntityIDs held in Component values
+	// as well as the IDs of the entities themselves.
+	prefix := fmt.Sprintf("\"eid-%x-", scene.ID())
+
+	return bytes.ReplaceAll(buf.Bytes(), []byte(prefix), []byte("\"eid-0-")), nil
+}
+
+type lineDifference struct {
+	number int
+	want   string
+	got    string
+}
+
+// firstDifference compares the given documents line by line, ignoring
+// differences in line endings.
+//
+// Returns the first line that differs and false, or true if the documents are
+// equal.
+func firstDifference(want, got []byte) (lineDifference, bool) {
+	wantLines := bytes.Split(bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n")), []byte("\n"))
+	gotLines := bytes.Split(bytes.ReplaceAll(got, []byte("\r\n"), []byte("\n")), []byte("\n"))
+
+	for i := range max(len(wantLines), len(gotLines)) {
+		diff := lineDifference{number: i + 1, want: "<end of file>", got: "<end of file>"}
+
+		if i < len(wantLines) {
+			diff.want = string(wantLines[i])
+		}
+
+		if i < len(gotLines) {
+			diff.got = string(gotLines[i])
+		}
+
+		if diff.want != diff.got {
+			return diff, false
+		}
+	}
+
+	return lineDifference{}, true
+}
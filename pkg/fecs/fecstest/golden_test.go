@@ -0,0 +1,139 @@
+package fecstest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func TestFirstDifference(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  string
+		got   string
+		equal bool
+		diff  lineDifference
+	}{
+		{"equal", "a\nb\n", "a\nb\n", true, lineDifference{}},
+		{"empty", "", "", true, lineDifference{}},
+		{"line endings", "a\r\nb\r\n", "a\nb\n", true, lineDifference{}},
+		{"changed line", "a\nb\nc", "a\nx\nc", false, lineDifference{2, "b", "x"}},
+		{"extra line", "a\n", "a\nb\n", false, lineDifference{2, "", "b"}},
+		{"missing line", "a\nb", "a", false, lineDifference{2, "b", "<end of file>"}},
+		{"missing trailing newline", "a\n", "a", false, lineDifference{2, "", "<end of file>"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff, equal := firstDifference([]byte(test.want), []byte(test.got))
+
+			if equal != test.equal || diff != test.diff {
+				t.Errorf("firstDifference() = %+v, %t, want %+v, %t", diff, equal, test.diff, test.equal)
+			}
+		})
+	}
+}
+
+// setUpdate sets the -fecstest.update flag for the duration of the test.
+func setUpdate(t *testing.T, value bool) {
+	old := *update
+	*update = value
+	t.Cleanup(func() { *update = old })
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "scene.golden")
+	fixture := Fixture{
+		"player": {&Position{X: 1, Y: 2}},
+		"camera": {&Position{}, &Tag{}},
+	}
+
+	first, firstIDs := NewScene(t, fixture)
+	attachTarget(first, firstIDs["camera"], firstIDs["player"])
+
+	rec := record(func(t TB) { AssertGolden(t, first, path) })
+	if !strings.Contains(rec.fatal, "-fecstest.update") {
+		t.Errorf("missing golden file failure = %q, want a hint about -fecstest.update", rec.fatal)
+	}
+
+	setUpdate(t, true)
+
+	if !AssertGolden(t, first, path) {
+		t.Fatalf("AssertGolden failed in update mode")
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(golden), fmt.Sprintf(`"eid-%x-`, first.ID())) || !strings.Contains(string(golden), `"eid-0-`) {
+		t.Errorf("golden file was not normalized:\n%s", golden)
+	}
+
+	setUpdate(t, false)
+
+	// The same contents in a scene with a different ID match the golden file.
+	second, secondIDs := NewScene(t, fixture)
+	attachTarget(second, secondIDs["camera"], secondIDs["player"])
+
+	if first.ID() == second.ID() {
+		t.Fatalf("expected the scenes to have different IDs")
+	}
+
+	AssertGolden(t, second, path)
+
+	// Changed contents are reported with the first differing line.
+	player := secondIDs["player"]
+	pos, _ := second.GetComponentByType(&player, positionType)
+	pos.(*Position).X = 5
+
+	rec = record(func(t TB) { AssertGolden(t, second, path) })
+
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], path+":") || !strings.Contains(rec.errors[0], `"X": 5`) {
+		t.Errorf("mismatch failures = %q, want one naming the golden file line", rec.errors)
+	}
+}
+
+func TestLoadScene(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.golden")
+
+	scene, ids := NewScene(t, Fixture{
+		"player": {&Position{X: 1, Y: 2}},
+		"wall":   {&Position{X: 10}, &Tag{}},
+	})
+
+	setUpdate(t, true)
+	AssertGolden(t, scene, path)
+	setUpdate(t, false)
+
+	loaded, loadedIDs := LoadScene(t, path)
+
+	AssertGolden(t, loaded, path)
+	AssertEntityCount(t, loaded, 2)
+
+	if len(loadedIDs) != len(ids) {
+		t.Errorf("LoadScene mapped %d entities, want %d", len(loadedIDs), len(ids))
+	}
+
+	for _, id := range loadedIDs {
+		if !loaded.ContainsEntity(&id) {
+			t.Errorf("LoadScene mapped an entity to %v, which is not in the loaded scene", id)
+		}
+	}
+
+	rec := record(func(t TB) { LoadScene(t, filepath.Join(filepath.Dir(path), "missing.golden")) })
+	if rec.fatal == "" {
+		t.Errorf("expected LoadScene to fail on a missing file")
+	}
+}
+
+// attachTarget attaches a Target component referencing the entity with the
+// given EntityID to the entity with the given EntityID.
+func attachTarget(scene fecs.Scene, id, of fecs.EntityID) {
+	scene.AttachComponent(&id, func() fecs.Component { return &Target{Of: of} })
+}
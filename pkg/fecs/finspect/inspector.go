@@ -0,0 +1,294 @@
+package finspect
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+const defaultEntityLimit = 500
+
+// New creates a new, empty Inspector.
+func New() *Inspector {
+	return &Inspector{scenes: make(map[fecs.SceneID]*registration, 4)}
+}
+
+// Inspector is an http.Handler that exposes the contents of registered Scenes
+// as JSON, along with a minimal HTML page for browsing them.
+//
+// The Inspector serves the following routes, relative to where it is mounted:
+//
+//	GET /                            HTML browser page
+//	GET /scenes                      list of registered scenes
+//	GET /scenes/{scene}/entities     list of entities in a scene
+//	GET /scenes/{scene}/entities/{entity}
+//	                                 components attached to an entity
+//
+// The entity list route accepts an optional "with" query parameter containing a
+// comma separated list of component type names or ids used to filter the
+// returned entities, as well as "offset" and "limit" parameters for paging.
+//
+// An Inspector is intended to be mounted under a prefix on a local mux, for
+// example:
+//
+//	mux.Handle("/debug/fecs/", http.StripPrefix("/debug/fecs", inspector))
+type Inspector struct {
+	lock   sync.RWMutex
+	scenes map[fecs.SceneID]*registration
+}
+
+type registration struct {
+	scene fecs.Scene
+	lock  sync.Locker
+}
+
+// Register makes the given Scene visible through this Inspector.
+//
+// The given lock is held by the Inspector for the duration of every read it
+// makes from the Scene.  To safely inspect a Scene while the simulation is
+// running, the simulation must hold the same lock whenever it mutates the
+// Scene.
+//
+// If the given lock is nil, this method will panic.
+func (i *Inspector) Register(scene fecs.Scene, lock sync.Locker) {
+	if lock == nil {
+		panic("attempted to register a scene with an inspector without a lock")
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.scenes[scene.ID()] = &registration{scene, lock}
+}
+
+// Unregister removes the given Scene from this Inspector.
+func (i *Inspector) Unregister(scene fecs.Scene) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	delete(i.scenes, scene.ID())
+}
+
+func (i *Inspector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(indexPage))
+	case len(parts) == 1 && parts[0] == "scenes":
+		i.serveScenes(w)
+	case len(parts) == 3 && parts[0] == "scenes" && parts[2] == "entities":
+		if reg, ok := i.lookup(w, parts[1]); ok {
+			serveEntities(w, r, reg)
+		}
+	case len(parts) == 4 && parts[0] == "scenes" && parts[2] == "entities":
+		if reg, ok := i.lookup(w, parts[1]); ok {
+			serveEntity(w, reg, parts[3])
+		}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (i *Inspector) lookup(w http.ResponseWriter, rawID string) (*registration, bool) {
+	id, err := strconv.ParseUint(rawID, 16, 32)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid scene id: "+rawID)
+		return nil, false
+	}
+
+	i.lock.RLock()
+	reg, ok := i.scenes[fecs.SceneID(id)]
+	i.lock.RUnlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown scene: "+rawID)
+		return nil, false
+	}
+
+	return reg, true
+}
+
+func (i *Inspector) serveScenes(w http.ResponseWriter) {
+	i.lock.RLock()
+	regs := make([]*registration, 0, len(i.scenes))
+	for _, reg := range i.scenes {
+		regs = append(regs, reg)
+	}
+	i.lock.RUnlock()
+
+	sort.Slice(regs, func(a, b int) bool { return regs[a].scene.ID() < regs[b].scene.ID() })
+
+	out := make([]sceneJSON, len(regs))
+	for n, reg := range regs {
+		reg.lock.Lock()
+		count := 0
+		for it := reg.scene.Entities(); it.HasNext(); it.Next() {
+			count++
+		}
+		reg.lock.Unlock()
+
+		out[n] = sceneJSON{
+			ID:       strconv.FormatUint(uint64(reg.scene.ID()), 16),
+			Name:     reg.scene.String(),
+			Entities: count,
+		}
+	}
+
+	writeJSON(w, out)
+}
+
+func serveEntities(w http.ResponseWriter, r *http.Request, reg *registration) {
+	query := r.URL.Query()
+
+	filter, err := parseTypes(query.Get("with"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offset, err := parseInt(query.Get("offset"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid offset: "+err.Error())
+		return
+	}
+
+	limit, err := parseInt(query.Get("limit"), defaultEntityLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit: "+err.Error())
+		return
+	}
+
+	out := entityPageJSON{Entities: make([]entityJSON, 0, min(limit, 64))}
+
+	reg.lock.Lock()
+	for it := reg.scene.Entities(filter...); it.HasNext(); out.Total++ {
+		id := it.Next()
+
+		if out.Total < offset || len(out.Entities) >= limit {
+			continue
+		}
+
+		out.Entities = append(out.Entities, newEntityJSON(reg.scene, &id))
+	}
+	reg.lock.Unlock()
+
+	writeJSON(w, out)
+}
+
+func serveEntity(w http.ResponseWriter, reg *registration, rawID string) {
+	id, err := fecs.ParseEntityID(rawID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+
+	if !reg.scene.ContainsEntity(&id) {
+		writeError(w, http.StatusNotFound, "unknown entity: "+rawID)
+		return
+	}
+
+	cids := reg.scene.Components(&id)
+	out := entityDetailJSON{
+		entityJSON: newEntityJSON(reg.scene, &id),
+		Components: make([]componentJSON, len(cids)),
+	}
+
+	for n := range cids {
+		out.Components[n] = componentJSON{
+			ID:   cids[n].String(),
+			Type: cids[n].Type().Name(),
+		}
+
+		if comp, ok := reg.scene.GetComponent(&cids[n]); ok {
+			if value, err := json.Marshal(comp); err != nil {
+				out.Components[n].Error = err.Error()
+			} else {
+				out.Components[n].Value = value
+			}
+		}
+	}
+
+	writeJSON(w, out)
+}
+
+func newEntityJSON(scene fecs.Scene, id *fecs.EntityID) entityJSON {
+	cids := scene.Components(id)
+	types := make([]string, len(cids))
+
+	for n := range cids {
+		types[n] = cids[n].Type().Name()
+	}
+
+	sort.Strings(types)
+
+	return entityJSON{ID: id.String(), Types: types}
+}
+
+// parseTypes parses a comma separated list of ComponentType names or
+// "ct-<hex>" ids.
+func parseTypes(raw string) ([]fecs.ComponentType, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	names := strings.Split(raw, ",")
+	out := make([]fecs.ComponentType, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		if ct, ok := fecs.ComponentTypeByName(name); ok {
+			out = append(out, ct)
+		} else if v, err := strconv.ParseUint(strings.TrimPrefix(name, "ct-"), 16, 8); err == nil && v > 0 {
+			out = append(out, fecs.ComponentType(v))
+		} else {
+			return nil, &unknownTypeError{name}
+		}
+	}
+
+	return out, nil
+}
+
+type unknownTypeError struct {
+	name string
+}
+
+func (e *unknownTypeError) Error() string {
+	return "unknown component type: " + e.name
+}
+
+func parseInt(raw string, def int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+
+	v, err := strconv.ParseUint(raw, 10, 31)
+	return int(v), err
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorJSON{message})
+}
@@ -0,0 +1,302 @@
+package finspect_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/finspect"
+)
+
+var (
+	positionType = fecs.NewNamedComponentType("Position")
+	tagType      = fecs.NewNamedComponentType("Tag")
+)
+
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func (*Position) Type() fecs.ComponentType {
+	return positionType
+}
+
+// Tag cannot be encoded as JSON.
+type Tag struct {
+	Callback func()
+}
+
+func (*Tag) Type() fecs.ComponentType {
+	return tagType
+}
+
+type fixture struct {
+	server *httptest.Server
+	scene  fecs.Scene
+	ids    []fecs.EntityID
+	gone   fecs.EntityID
+}
+
+// newFixture serves an Inspector holding a scene of five entities, each with a
+// Position, the first two of which also have a Tag, and an empty second scene.
+func newFixture(t *testing.T) *fixture {
+	var lock sync.Mutex
+	out := &fixture{scene: fecs.NewScene()}
+
+	for i := range 5 {
+		id := out.scene.NewEntity()
+		out.scene.AttachComponent(&id, func() fecs.Component { return &Position{float64(i), 1} })
+
+		if i < 2 {
+			out.scene.AttachComponent(&id, func() fecs.Component { return new(Tag) })
+		}
+
+		out.ids = append(out.ids, id)
+	}
+
+	out.gone = out.scene.NewEntity()
+	out.scene.DestroyEntity(&out.gone)
+
+	inspector := finspect.New()
+	inspector.Register(out.scene, &lock)
+	inspector.Register(fecs.NewScene(), &lock)
+
+	out.server = httptest.NewServer(inspector)
+	t.Cleanup(out.server.Close)
+
+	return out
+}
+
+func (f *fixture) scenePath() string {
+	return "/scenes/" + strconv.FormatUint(uint64(f.scene.ID()), 16)
+}
+
+// get requests the given path, checks the response status and decodes the
+// response body into out.
+func (f *fixture) get(t *testing.T, path string, status int, out any) {
+	t.Helper()
+
+	res, err := http.Get(f.server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != status {
+		t.Fatalf("GET %s: status %d, want %d", path, res.StatusCode, status)
+	}
+
+	if out == nil {
+		return
+	}
+
+	if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("GET %s: Content-Type %q", path, ct)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		t.Fatalf("GET %s: %s", path, err)
+	}
+}
+
+type entityPage struct {
+	Total    int `json:"total"`
+	Entities []struct {
+		ID    string   `json:"id"`
+		Types []string `json:"types"`
+	} `json:"entities"`
+}
+
+func (p *entityPage) ids() []string {
+	var out []string
+	for _, e := range p.Entities {
+		out = append(out, e.ID)
+	}
+	return out
+}
+
+func TestInspectorScenes(t *testing.T) {
+	f := newFixture(t)
+
+	var scenes []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Entities int    `json:"entities"`
+	}
+
+	f.get(t, "/scenes", http.StatusOK, &scenes)
+
+	if len(scenes) != 2 {
+		t.Fatalf("got %d scenes, want 2", len(scenes))
+	}
+
+	if scenes[0].ID != f.scenePath()[len("/scenes/"):] || scenes[0].Name != f.scene.String() || scenes[0].Entities != 5 {
+		t.Errorf("first scene = %+v", scenes[0])
+	}
+
+	if scenes[1].Entities != 0 {
+		t.Errorf("second scene = %+v", scenes[1])
+	}
+
+	res, err := http.Get(f.server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("GET /: status %d, Content-Type %q", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+}
+
+func TestInspectorEntities(t *testing.T) {
+	f := newFixture(t)
+
+	all := make([]string, len(f.ids))
+	for i := range f.ids {
+		all[i] = f.ids[i].String()
+	}
+
+	tests := []struct {
+		query string
+		total int
+		want  []string
+	}{
+		{"", 5, all},
+		{"?with=Tag", 2, all[:2]},
+		{"?with=Position,%20Tag", 2, all[:2]},
+		{"?with=" + tagType.String(), 2, all[:2]},
+		{"?offset=1&limit=2", 5, all[1:3]},
+		{"?offset=4&limit=9", 5, all[4:]},
+		{"?offset=9", 5, nil},
+		{"?with=Position&limit=0", 5, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			var page entityPage
+			f.get(t, f.scenePath()+"/entities"+test.query, http.StatusOK, &page)
+
+			if page.Total != test.total || !slices.Equal(page.ids(), test.want) {
+				t.Errorf("got %d of %v, want %d of %v", page.Total, page.ids(), test.total, test.want)
+			}
+		})
+	}
+
+	var page entityPage
+	f.get(t, f.scenePath()+"/entities?limit=1", http.StatusOK, &page)
+
+	if want := []string{"Position", "Tag"}; !slices.Equal(page.Entities[0].Types, want) {
+		t.Errorf("Types = %v, want %v", page.Entities[0].Types, want)
+	}
+}
+
+func TestInspectorEntity(t *testing.T) {
+	f := newFixture(t)
+
+	var entity struct {
+		ID         string   `json:"id"`
+		Types      []string `json:"types"`
+		Components []struct {
+			ID    string          `json:"id"`
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+			Error string          `json:"error"`
+		} `json:"components"`
+	}
+
+	f.get(t, f.scenePath()+"/entities/"+f.ids[0].String(), http.StatusOK, &entity)
+
+	if entity.ID != f.ids[0].String() || len(entity.Components) != 2 {
+		t.Fatalf("entity = %+v", entity)
+	}
+
+	for _, c := range entity.Components {
+		switch c.Type {
+		case "Position":
+			if string(c.Value) != `{"x":0,"y":1}` || c.Error != "" {
+				t.Errorf("Position = %s, %q", c.Value, c.Error)
+			}
+		case "Tag":
+			if c.Value != nil || c.Error == "" {
+				t.Errorf("Tag = %s, %q, want an error", c.Value, c.Error)
+			}
+		default:
+			t.Errorf("unexpected component %+v", c)
+		}
+	}
+}
+
+func TestInspectorErrors(t *testing.T) {
+	f := newFixture(t)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{f.scenePath() + "/entities/" + f.gone.String(), http.StatusNotFound},
+		{f.scenePath() + "/entities/not-an-id", http.StatusBadRequest},
+		{"/scenes/ffffffff/entities", http.StatusNotFound},
+		{"/scenes/not-hex/entities", http.StatusBadRequest},
+		{f.scenePath() + "/entities?with=Missing", http.StatusBadRequest},
+		{f.scenePath() + "/entities?with=ct-0", http.StatusBadRequest},
+		{f.scenePath() + "/entities?offset=-1", http.StatusBadRequest},
+		{f.scenePath() + "/entities?limit=lots", http.StatusBadRequest},
+		{f.scenePath(), http.StatusNotFound},
+		{"/elsewhere", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			var body struct {
+				Error string `json:"error"`
+			}
+
+			f.get(t, test.path, test.status, &body)
+
+			if body.Error == "" {
+				t.Errorf("no error message")
+			}
+		})
+	}
+
+	res, err := http.Post(f.server.URL+"/scenes", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusMethodNotAllowed || res.Header.Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST: status %d, Allow %q", res.StatusCode, res.Header.Get("Allow"))
+	}
+}
+
+// TestInspectorConcurrentRegistration reads ComponentType names through the
+// Inspector while new ComponentTypes are registered, for the race detector to
+// check.
+func TestInspectorConcurrentRegistration(t *testing.T) {
+	f := newFixture(t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := range 20 {
+			fecs.NewNamedComponentType(fmt.Sprintf("Concurrent%d", i))
+		}
+	}()
+
+	for range 20 {
+		var page entityPage
+		f.get(t, f.scenePath()+"/entities?with=Tag", http.StatusOK, &page)
+	}
+
+	<-done
+}
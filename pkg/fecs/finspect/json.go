@@ -0,0 +1,35 @@
+package finspect
+
+import "encoding/json"
+
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+type sceneJSON struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Entities int    `json:"entities"`
+}
+
+type entityPageJSON struct {
+	Total    int          `json:"total"`
+	Entities []entityJSON `json:"entities"`
+}
+
+type entityJSON struct {
+	ID    string   `json:"id"`
+	Types []string `json:"types"`
+}
+
+type entityDetailJSON struct {
+	entityJSON
+	Components []componentJSON `json:"components"`
+}
+
+type componentJSON struct {
+	ID    string          `json:"id"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
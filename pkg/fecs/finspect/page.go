@@ -0,0 +1,88 @@
+package finspect
+
+// indexPage is the minimal HTML page served at the root of an Inspector.
+//
+// All requests made by the page are relative so that it works regardless of
+// where the Inspector is mounted.
+const indexPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>fecs inspector</title>
+<style>
+body { font-family: monospace; margin: 1em; }
+nav a, li a { cursor: pointer; color: #06c; }
+.cols { display: flex; gap: 2em; }
+.cols > div { flex: 1; overflow: auto; }
+pre { background: #f4f4f4; padding: .5em; }
+</style>
+</head>
+<body>
+<h1>fecs inspector</h1>
+<nav id="scenes"></nav>
+<p><label>with: <input id="with" placeholder="Position,Velocity"></label> <button id="filter">filter</button></p>
+<div class="cols">
+<div><ul id="entities"></ul></div>
+<div id="entity"></div>
+</div>
+<script>
+let scene = null;
+
+function el(tag, text, onclick) {
+  const e = document.createElement(tag);
+  e.textContent = text;
+  if (onclick) e.onclick = onclick;
+  return e;
+}
+
+async function get(path) {
+  const res = await fetch(path);
+  const body = await res.json();
+  if (!res.ok) throw new Error(body.error);
+  return body;
+}
+
+async function loadScenes() {
+  const nav = document.getElementById("scenes");
+  nav.replaceChildren();
+  for (const s of await get("scenes")) {
+    nav.append(el("a", s.name + " (" + s.entities + ")", () => loadEntities(s.id)), " ");
+  }
+}
+
+async function loadEntities(id) {
+  scene = id;
+  const list = document.getElementById("entities");
+  const q = encodeURIComponent(document.getElementById("with").value);
+  try {
+    const page = await get("scenes/" + id + "/entities?with=" + q);
+    list.replaceChildren(el("li", page.total + " entities"));
+    for (const e of page.entities) {
+      const li = el("li", "");
+      li.append(el("a", e.id, () => loadEntity(e.id)), " " + e.types.join(", "));
+      list.append(li);
+    }
+  } catch (err) {
+    list.replaceChildren(el("li", err.message));
+  }
+}
+
+async function loadEntity(id) {
+  const out = document.getElementById("entity");
+  try {
+    const e = await get("scenes/" + scene + "/entities/" + id);
+    out.replaceChildren(el("h2", e.id));
+    for (const c of e.components) {
+      out.append(el("h3", c.type + " " + c.id), el("pre", c.error || JSON.stringify(c.value, null, 2)));
+    }
+  } catch (err) {
+    out.replaceChildren(el("p", err.message));
+  }
+}
+
+document.getElementById("filter").onclick = () => scene && loadEntities(scene);
+loadScenes();
+</script>
+</body>
+</html>
+`
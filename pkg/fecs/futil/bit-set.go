@@ -0,0 +1,246 @@
+package futil
+
+import "math/bits"
+
+// BitSet is a dynamically sized set of non-negative integers, stored as a
+// packed sequence of bits.
+type BitSet interface {
+
+	// Len returns the number of bits the set can currently hold without growing.
+	Len() int
+
+	// Count returns the number of bits that are currently set.
+	Count() int
+
+	// IsEmpty returns a boolean indicator for whether any bits are set.
+	IsEmpty() bool
+
+	// Clear unsets all bits in the set.
+	Clear()
+
+	// Set sets the bit at the given index, growing the set if necessary.
+	//
+	// If the given index is less than zero, this method will panic.
+	Set(index int)
+
+	// Unset unsets the bit at the given index.
+	//
+	// If the given index is less than zero, this method will panic.
+	Unset(index int)
+
+	// Toggle flips the bit at the given index, growing the set if necessary.
+	//
+	// If the given index is less than zero, this method will panic.
+	Toggle(index int)
+
+	// Has tests whether the bit at the given index is set.
+	//
+	// If the given index is less than zero, this method will panic.
+	Has(index int) bool
+
+	// HasAll tests whether every bit that is set in the given BitSet is also set
+	// in this BitSet.
+	HasAll(other BitSet) bool
+
+	// HasAny tests whether any bit that is set in the given BitSet is also set
+	// in this BitSet.
+	HasAny(other BitSet) bool
+
+	// And unsets every bit in this BitSet that is not set in the given BitSet.
+	And(other BitSet)
+
+	// Or sets every bit in this BitSet that is set in the given BitSet.
+	Or(other BitSet)
+
+	// AndNot unsets every bit in this BitSet that is set in the given BitSet.
+	AndNot(other BitSet)
+
+	// NextSet returns the index of the first set bit at or after the given
+	// index, and true, or -1 and false if there is no such bit.
+	NextSet(from int) (int, bool)
+
+	// Iterator returns a new Iterator instance over the indices of the bits that
+	// are currently set, in ascending order.
+	Iterator() Iterator[int]
+}
+
+// NewBitSet returns a new BitSet instance with room for at least the given
+// number of bits before it needs to grow.
+func NewBitSet(capacity int) BitSet {
+	return &bitSet{words: make([]uint64, (max(capacity, 0)+63)/64)}
+}
+
+type bitSet struct {
+	words []uint64
+}
+
+func (b *bitSet) Len() int {
+	return len(b.words) * 64
+}
+
+func (b *bitSet) Count() int {
+	out := 0
+
+	for _, w := range b.words {
+		out += bits.OnesCount64(w)
+	}
+
+	return out
+}
+
+func (b *bitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *bitSet) Clear() {
+	clear(b.words)
+}
+
+func (b *bitSet) Set(index int) {
+	checkBitIndex(index)
+	b.ensureCapacity(index/64 + 1)
+	b.words[index/64] |= 1 << (index % 64)
+}
+
+func (b *bitSet) Unset(index int) {
+	checkBitIndex(index)
+
+	if index/64 < len(b.words) {
+		b.words[index/64] &^= 1 << (index % 64)
+	}
+}
+
+func (b *bitSet) Toggle(index int) {
+	checkBitIndex(index)
+	b.ensureCapacity(index/64 + 1)
+	b.words[index/64] ^= 1 << (index % 64)
+}
+
+func (b *bitSet) Has(index int) bool {
+	checkBitIndex(index)
+	return index/64 < len(b.words) && b.words[index/64]&(1<<(index%64)) != 0
+}
+
+func (b *bitSet) HasAll(other BitSet) bool {
+	o := other.(*bitSet)
+
+	for i, w := range o.words {
+		if w != 0 && (i >= len(b.words) || b.words[i]&w != w) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *bitSet) HasAny(other BitSet) bool {
+	o := other.(*bitSet)
+
+	for i := 0; i < min(len(b.words), len(o.words)); i++ {
+		if b.words[i]&o.words[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *bitSet) And(other BitSet) {
+	o := other.(*bitSet)
+
+	for i := range b.words {
+		if i < len(o.words) {
+			b.words[i] &= o.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+func (b *bitSet) Or(other BitSet) {
+	o := other.(*bitSet)
+	b.ensureCapacity(len(o.words))
+
+	for i, w := range o.words {
+		b.words[i] |= w
+	}
+}
+
+func (b *bitSet) AndNot(other BitSet) {
+	o := other.(*bitSet)
+
+	for i := 0; i < min(len(b.words), len(o.words)); i++ {
+		b.words[i] &^= o.words[i]
+	}
+}
+
+func (b *bitSet) NextSet(from int) (int, bool) {
+	checkBitIndex(from)
+
+	i := from / 64
+	if i >= len(b.words) {
+		return -1, false
+	}
+
+	// Mask off the bits below the starting index in the first word.
+	w := b.words[i] & (^uint64(0) << (from % 64))
+
+	for {
+		if w != 0 {
+			return i*64 + bits.TrailingZeros64(w), true
+		}
+
+		i++
+		if i >= len(b.words) {
+			return -1, false
+		}
+
+		w = b.words[i]
+	}
+}
+
+func (b *bitSet) Iterator() Iterator[int] {
+	return &bitSetIterator{set: b}
+}
+
+func (b *bitSet) ensureCapacity(words int) {
+	if len(b.words) >= words {
+		return
+	}
+
+	tmp := make([]uint64, max(words, len(b.words)*2))
+	copy(tmp, b.words)
+	b.words = tmp
+}
+
+func checkBitIndex(index int) {
+	if index < 0 {
+		panic("bit index must not be negative")
+	}
+}
+
+type bitSetIterator struct {
+	set  *bitSet
+	from int
+}
+
+func (b *bitSetIterator) HasNext() bool {
+	_, ok := b.set.NextSet(b.from)
+	return ok
+}
+
+func (b *bitSetIterator) Next() int {
+	idx, ok := b.set.NextSet(b.from)
+	if !ok {
+		panic("no such element")
+	}
+
+	b.from = idx + 1
+	return idx
+}
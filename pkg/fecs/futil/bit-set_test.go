@@ -0,0 +1,107 @@
+package futil
+
+import (
+	"slices"
+	"testing"
+)
+
+func bitSetOf(indices ...int) BitSet {
+	out := NewBitSet(0)
+	for _, i := range indices {
+		out.Set(i)
+	}
+	return out
+}
+
+func TestBitSet(t *testing.T) {
+	b := NewBitSet(10)
+
+	if b.Len() != 64 || !b.IsEmpty() {
+		t.Errorf("NewBitSet(10) has Len() %d", b.Len())
+	}
+
+	// Setting a bit past the end grows the set.
+	for _, i := range []int{0, 63, 64, 200, 5} {
+		b.Set(i)
+	}
+
+	if b.Len() < 201 {
+		t.Errorf("Len() after Set(200) = %d", b.Len())
+	}
+
+	if got := Collect(b.Iterator()); !slices.Equal(got, []int{0, 5, 63, 64, 200}) {
+		t.Errorf("set bits = %v", got)
+	}
+
+	if b.Count() != 5 || !b.Has(63) || b.Has(62) || b.Has(10_000) {
+		t.Errorf("Count() = %d, Has() is wrong", b.Count())
+	}
+
+	b.Unset(63)
+	b.Unset(10_000)
+	b.Toggle(5)
+	b.Toggle(300)
+
+	if got := Collect(b.Iterator()); !slices.Equal(got, []int{0, 64, 200, 300}) {
+		t.Errorf("after Unset and Toggle, set bits = %v", got)
+	}
+
+	for _, from := range []struct{ from, want int }{{0, 0}, {1, 64}, {64, 64}, {65, 200}, {301, -1}, {5000, -1}} {
+		if got, ok := b.NextSet(from.from); got != from.want || ok != (from.want >= 0) {
+			t.Errorf("NextSet(%d) = %d, %t, want %d", from.from, got, ok, from.want)
+		}
+	}
+
+	b.Clear()
+	if !b.IsEmpty() || b.Iterator().HasNext() {
+		t.Errorf("Clear() left bits set")
+	}
+
+	mustPanic(t, "Set(-1)", func() { b.Set(-1) })
+	mustPanic(t, "Has(-1)", func() { b.Has(-1) })
+	mustPanic(t, "Iterator().Next() on an empty set", func() { b.Iterator().Next() })
+}
+
+func TestBitSetOperations(t *testing.T) {
+	tests := []struct {
+		name         string
+		a, b         []int
+		and, or, not []int
+		all, any     bool
+	}{
+		{"disjoint", []int{1, 2}, []int{3, 4}, nil, []int{1, 2, 3, 4}, []int{1, 2}, false, false},
+		{"subset", []int{1, 2, 3}, []int{2}, []int{2}, []int{1, 2, 3}, []int{1, 3}, true, true},
+		{"other is longer", []int{1}, []int{1, 130}, []int{1}, []int{1, 130}, nil, false, true},
+		{"this is longer", []int{1, 130}, []int{130}, []int{130}, []int{1, 130}, []int{1}, true, true},
+		{"empty other", []int{1, 70}, nil, nil, []int{1, 70}, []int{1, 70}, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := bitSetOf(test.a...), bitSetOf(test.b...)
+
+			if a.HasAll(b) != test.all || a.HasAny(b) != test.any {
+				t.Errorf("HasAll(), HasAny() = %t, %t, want %t, %t", a.HasAll(b), a.HasAny(b), test.all, test.any)
+			}
+
+			and := bitSetOf(test.a...)
+			and.And(b)
+
+			or := bitSetOf(test.a...)
+			or.Or(b)
+
+			not := bitSetOf(test.a...)
+			not.AndNot(b)
+
+			for _, c := range []struct {
+				name string
+				set  BitSet
+				want []int
+			}{{"And", and, test.and}, {"Or", or, test.or}, {"AndNot", not, test.not}} {
+				if got := Collect(c.set.Iterator()); !slices.Equal(got, c.want) && len(got)+len(c.want) > 0 {
+					t.Errorf("%s() = %v, want %v", c.name, got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package futil
+
+const dequeGrowthFactor float32 = 1.5
+
+// Deque is a double-ended queue implementation over generic type T, backed by
+// a growable ring buffer.
+type Deque[T interface{}] interface {
+
+	// Size returns the number of elements currently in the deque.
+	Size() int
+
+	// IsEmpty returns a boolean indicator for whether the deque is empty or not.
+	IsEmpty() bool
+
+	// Clear removes all elements from the deque.
+	Clear()
+
+	// PushFront pushes a new value onto the front of the deque.
+	PushFront(value T)
+
+	// PushBack pushes a new value onto the back of the deque.
+	PushBack(value T)
+
+	// PopFront removes and returns the value at the front of the deque.
+	//
+	// If the deque is empty, this method will panic.
+	PopFront() T
+
+	// PopBack removes and returns the value at the back of the deque.
+	//
+	// If the deque is empty, this method will panic.
+	PopBack() T
+
+	// PeekFront returns the value at the front of the deque without removing it.
+	//
+	// If the deque is empty, this method will panic.
+	PeekFront() T
+
+	// PeekBack returns the value at the back of the deque without removing it.
+	//
+	// If the deque is empty, this method will panic.
+	PeekBack() T
+
+	// Get returns the value at the given index, counting from the front of the
+	// deque.
+	//
+	// If the given index is less than zero or is greater than or equal to Size,
+	// this method will panic.
+	Get(index int) T
+
+	// Iterator returns a new Iterator instance over the values currently in the
+	// deque, from front to back.
+	Iterator() Iterator[T]
+}
+
+// NewDeque returns a new Deque instance.
+func NewDeque[T interface{}]() Deque[T] {
+	return &deque[T]{values: make([]T, initialCapacity)}
+}
+
+type deque[T interface{}] struct {
+	values []T
+	head   int
+	size   int
+}
+
+func (d *deque[T]) Size() int {
+	return d.size
+}
+
+func (d *deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+func (d *deque[T]) Clear() {
+	d.values = make([]T, initialCapacity)
+	d.head = 0
+	d.size = 0
+}
+
+func (d *deque[T]) PushFront(value T) {
+	d.ensureCapacity(d.size + 1)
+	d.head = d.wrap(d.head - 1)
+	d.values[d.head] = value
+	d.size++
+}
+
+func (d *deque[T]) PushBack(value T) {
+	d.ensureCapacity(d.size + 1)
+	d.values[d.wrap(d.head+d.size)] = value
+	d.size++
+}
+
+func (d *deque[T]) PopFront() T {
+	if d.size == 0 {
+		panic("attempted to pop an empty deque")
+	}
+
+	var zero T
+	out := d.values[d.head]
+	d.values[d.head] = zero
+	d.head = d.wrap(d.head + 1)
+	d.size--
+
+	return out
+}
+
+func (d *deque[T]) PopBack() T {
+	if d.size == 0 {
+		panic("attempted to pop an empty deque")
+	}
+
+	var zero T
+	idx := d.wrap(d.head + d.size - 1)
+	out := d.values[idx]
+	d.values[idx] = zero
+	d.size--
+
+	return out
+}
+
+func (d *deque[T]) PeekFront() T {
+	if d.size == 0 {
+		panic("no such element")
+	}
+
+	return d.values[d.head]
+}
+
+func (d *deque[T]) PeekBack() T {
+	if d.size == 0 {
+		panic("no such element")
+	}
+
+	return d.values[d.wrap(d.head+d.size-1)]
+}
+
+func (d *deque[T]) Get(index int) T {
+	if index < 0 || index >= d.size {
+		panic("no such element")
+	}
+
+	return d.values[d.wrap(d.head+index)]
+}
+
+func (d *deque[T]) Iterator() Iterator[T] {
+	return &indexIterator[T]{get: d.Get, size: d.size}
+}
+
+func (d *deque[T]) wrap(index int) int {
+	l := len(d.values)
+
+	if index < 0 {
+		return index + l
+	} else if index >= l {
+		return index - l
+	}
+
+	return index
+}
+
+func (d *deque[T]) ensureCapacity(size int) {
+	if len(d.values) >= size {
+		return
+	}
+
+	newSize := max(int(float32(len(d.values))*dequeGrowthFactor), size)
+
+	tmp := make([]T, newSize)
+	n := copy(tmp, d.values[d.head:min(d.head+d.size, len(d.values))])
+	copy(tmp[n:], d.values[:d.size-n])
+
+	d.values = tmp
+	d.head = 0
+}
+
+// indexIterator is an Iterator over the values of an indexed collection.
+type indexIterator[T interface{}] struct {
+	get   func(int) T
+	index int
+	size  int
+}
+
+func (i *indexIterator[T]) HasNext() bool {
+	return i.index < i.size
+}
+
+func (i *indexIterator[T]) Next() T {
+	if !i.HasNext() {
+		panic("no such element")
+	}
+
+	i.index++
+	return i.get(i.index - 1)
+}
@@ -0,0 +1,124 @@
+package futil
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// mustPanic fails the test if the given function does not panic.
+func mustPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+
+	fn()
+}
+
+// dequeOps runs the given operations against both a Deque and a slice model,
+// checking that they agree after every step.
+//
+// Each operation is one of 'F' (push front), 'B' (push back), 'f' (pop front)
+// or 'b' (pop back). Pops on an empty deque are skipped.
+func dequeOps(t *testing.T, d Deque[int], ops string) {
+	t.Helper()
+
+	var model []int
+
+	for i, op := range ops {
+		if (op == 'f' || op == 'b') && len(model) == 0 {
+			continue
+		}
+
+		switch op {
+		case 'F':
+			d.PushFront(i)
+			model = slices.Insert(model, 0, i)
+		case 'B':
+			d.PushBack(i)
+			model = append(model, i)
+		case 'f':
+			if got := d.PopFront(); got != model[0] {
+				t.Fatalf("op %d: PopFront() = %d, want %d", i, got, model[0])
+			}
+			model = model[1:]
+		case 'b':
+			if got := d.PopBack(); got != model[len(model)-1] {
+				t.Fatalf("op %d: PopBack() = %d, want %d", i, got, model[len(model)-1])
+			}
+			model = model[:len(model)-1]
+		}
+
+		if d.Size() != len(model) || d.IsEmpty() != (len(model) == 0) {
+			t.Fatalf("op %d: Size() = %d, want %d", i, d.Size(), len(model))
+		}
+
+		if got := Collect(d.Iterator()); !slices.Equal(got, model) {
+			t.Fatalf("op %d: contents = %v, want %v", i, got, model)
+		}
+
+		if len(model) > 0 && (d.PeekFront() != model[0] || d.PeekBack() != model[len(model)-1]) {
+			t.Fatalf("op %d: PeekFront(), PeekBack() = %d, %d, want %d, %d", i, d.PeekFront(), d.PeekBack(), model[0], model[len(model)-1])
+		}
+	}
+}
+
+func repeat(op string, n int) string {
+	out := make([]byte, 0, len(op)*n)
+	for range n {
+		out = append(out, op...)
+	}
+	return string(out)
+}
+
+func TestDeque(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	mixed := make([]byte, 2000)
+	for i := range mixed {
+		// Bias towards pushes so the deque grows while wrapping.
+		mixed[i] = "FFBBfb"[random.IntN(6)]
+	}
+
+	tests := []struct {
+		name string
+		ops  string
+	}{
+		{"push back past capacity", repeat("B", 100)},
+		{"push front past capacity", repeat("F", 100)},
+		{"queue wraps without growing", repeat("B", 20) + repeat("Bf", 100)},
+		{"stack at the front", repeat("F", 40) + repeat("f", 40)},
+		{"grow while wrapped", repeat("B", 20) + repeat("f", 15) + repeat("B", 60)},
+		{"grow while wrapped at the front", repeat("F", 10) + repeat("B", 10) + repeat("F", 50)},
+		{"alternate ends", repeat("FBfb", 50)},
+		{"random", string(mixed)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dequeOps(t, NewDeque[int](), test.ops)
+		})
+	}
+}
+
+func TestDequeEmpty(t *testing.T) {
+	d := NewDeque[int]()
+
+	mustPanic(t, "PopFront()", func() { d.PopFront() })
+	mustPanic(t, "PopBack()", func() { d.PopBack() })
+	mustPanic(t, "PeekFront()", func() { d.PeekFront() })
+	mustPanic(t, "PeekBack()", func() { d.PeekBack() })
+	mustPanic(t, "Get(0)", func() { d.Get(0) })
+
+	d.PushBack(1)
+	mustPanic(t, "Get(-1)", func() { d.Get(-1) })
+	mustPanic(t, "Get(1)", func() { d.Get(1) })
+
+	d.Clear()
+	if !d.IsEmpty() || d.Iterator().HasNext() {
+		t.Errorf("Clear() left values behind")
+	}
+}
@@ -8,7 +8,7 @@ package futil
 // Returns either the mapped value or the default value for the type R depending
 // on whether the given input value is nil.
 func MapIfPresent[T, R interface{}](value T, mapper func(T) R) (out R) {
-	if value != nil {
+	if any(value) != nil {
 		out = mapper(value)
 	}
 
@@ -20,7 +20,7 @@ func MapIfPresent[T, R interface{}](value T, mapper func(T) R) (out R) {
 //
 // It doesn't make sense to call this function on values that are not nillable.
 func CallIfPresent[T interface{}](value T, fun func(T)) {
-	if value != nil {
+	if any(value) != nil {
 		fun(value)
 	}
 }
@@ -0,0 +1,117 @@
+package futil
+
+import "math"
+
+// AABB is a 3D axis-aligned bounding box.
+type AABB[T Numeric] struct {
+	Min Vec3[T]
+	Max Vec3[T]
+}
+
+// NewAABB returns the smallest AABB containing all the given points.
+//
+// If no points are given, the zero AABB is returned.
+func NewAABB[T Numeric](points ...Vec3[T]) (out AABB[T]) {
+	if len(points) == 0 {
+		return
+	}
+
+	out.Min, out.Max = points[0], points[0]
+
+	for _, p := range points[1:] {
+		out = out.Expand(p)
+	}
+
+	return
+}
+
+// Center returns the point at the center of this AABB.
+func (a AABB[T]) Center() Vec3[T] {
+	return a.Min.Lerp(a.Max, 0.5)
+}
+
+// Size returns the extent of this AABB along each axis.
+func (a AABB[T]) Size() Vec3[T] {
+	return a.Max.Sub(a.Min)
+}
+
+// Contains tests whether the given point lies within or on the boundary of this
+// AABB.
+func (a AABB[T]) Contains(p Vec3[T]) bool {
+	return p[0] >= a.Min[0] && p[0] <= a.Max[0] &&
+		p[1] >= a.Min[1] && p[1] <= a.Max[1] &&
+		p[2] >= a.Min[2] && p[2] <= a.Max[2]
+}
+
+// Intersects tests whether this AABB overlaps or touches the given AABB.
+func (a AABB[T]) Intersects(o AABB[T]) bool {
+	return a.Min[0] <= o.Max[0] && a.Max[0] >= o.Min[0] &&
+		a.Min[1] <= o.Max[1] && a.Max[1] >= o.Min[1] &&
+		a.Min[2] <= o.Max[2] && a.Max[2] >= o.Min[2]
+}
+
+// Expand returns the smallest AABB containing both this AABB and the given
+// point.
+func (a AABB[T]) Expand(p Vec3[T]) AABB[T] {
+	return AABB[T]{
+		Vec3[T]{min(a.Min[0], p[0]), min(a.Min[1], p[1]), min(a.Min[2], p[2])},
+		Vec3[T]{max(a.Max[0], p[0]), max(a.Max[1], p[1]), max(a.Max[2], p[2])},
+	}
+}
+
+// Union returns the smallest AABB containing both this AABB and the given AABB.
+func (a AABB[T]) Union(o AABB[T]) AABB[T] {
+	return a.Expand(o.Min).Expand(o.Max)
+}
+
+// Ray is a half-line starting at Origin and extending infinitely in Direction.
+type Ray[T Numeric] struct {
+	Origin    Vec3[T]
+	Direction Vec3[T]
+}
+
+// At returns the point at the given distance along this Ray, measured in
+// multiples of the Ray's Direction.
+func (r Ray[T]) At(t float64) Vec3[T] {
+	return Vec3[T]{
+		T(float64(r.Origin[0]) + float64(r.Direction[0])*t),
+		T(float64(r.Origin[1]) + float64(r.Direction[1])*t),
+		T(float64(r.Origin[2]) + float64(r.Direction[2])*t),
+	}
+}
+
+// IntersectAABB tests whether this Ray intersects the given AABB.
+//
+// If it does, the distance along the Ray to the first point of intersection,
+// measured in multiples of the Ray's Direction, is returned along with true.
+// If the Ray's Origin lies within the AABB, the returned distance is 0.
+func (r Ray[T]) IntersectAABB(box AABB[T]) (float64, bool) {
+	near, far := 0.0, math.Inf(1)
+
+	for i := 0; i < 3; i++ {
+		o, d := float64(r.Origin[i]), float64(r.Direction[i])
+		lo, hi := float64(box.Min[i]), float64(box.Max[i])
+
+		if d == 0 {
+			if o < lo || o > hi {
+				return 0, false
+			}
+
+			continue
+		}
+
+		t1, t2 := (lo-o)/d, (hi-o)/d
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+
+		near = max(near, t1)
+		far = min(far, t2)
+
+		if near > far {
+			return 0, false
+		}
+	}
+
+	return near, true
+}
@@ -0,0 +1,114 @@
+package futil
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAABB(t *testing.T) {
+	box := NewAABB(Vec3[int]{4, 0, 2}, Vec3[int]{0, 4, 0}, Vec3[int]{2, 2, -2})
+
+	if box != (AABB[int]{Vec3[int]{0, 0, -2}, Vec3[int]{4, 4, 2}}) {
+		t.Fatalf("NewAABB() = %v", box)
+	}
+
+	if box.Center() != (Vec3[int]{2, 2, 0}) || box.Size() != (Vec3[int]{4, 4, 4}) {
+		t.Errorf("Center(), Size() = %v, %v", box.Center(), box.Size())
+	}
+
+	if got := NewAABB[int](); got != (AABB[int]{}) {
+		t.Errorf("NewAABB() with no points = %v", got)
+	}
+
+	if got := NewAABB(Vec3[int]{1, 2, 3}); got.Min != got.Max || !got.Contains(Vec3[int]{1, 2, 3}) {
+		t.Errorf("NewAABB() with one point = %v", got)
+	}
+
+	union := box.Union(AABB[int]{Vec3[int]{-1, 1, 1}, Vec3[int]{1, 5, 1}})
+	if union != (AABB[int]{Vec3[int]{-1, 0, -2}, Vec3[int]{4, 5, 2}}) {
+		t.Errorf("Union() = %v", union)
+	}
+}
+
+func TestAABBContains(t *testing.T) {
+	box := AABB[float64]{Vec3[float64]{0, 0, 0}, Vec3[float64]{1, 2, 3}}
+
+	tests := []struct {
+		name string
+		p    Vec3[float64]
+		want bool
+	}{
+		{"inside", Vec3[float64]{0.5, 1, 1.5}, true},
+		{"min corner", box.Min, true},
+		{"max corner", box.Max, true},
+		{"on a face", Vec3[float64]{1, 1, 1}, true},
+		{"below on x", Vec3[float64]{-0.1, 1, 1}, false},
+		{"above on y", Vec3[float64]{0.5, 2.1, 1}, false},
+		{"above on z", Vec3[float64]{0.5, 1, 3.1}, false},
+	}
+
+	for _, test := range tests {
+		if got := box.Contains(test.p); got != test.want {
+			t.Errorf("%s: Contains(%v) = %t, want %t", test.name, test.p, got, test.want)
+		}
+	}
+}
+
+func TestAABBIntersects(t *testing.T) {
+	box := AABB[float64]{Vec3[float64]{0, 0, 0}, Vec3[float64]{2, 2, 2}}
+
+	tests := []struct {
+		name  string
+		other AABB[float64]
+		want  bool
+	}{
+		{"same", box, true},
+		{"overlapping", AABB[float64]{Vec3[float64]{1, 1, 1}, Vec3[float64]{3, 3, 3}}, true},
+		{"contained", AABB[float64]{Vec3[float64]{0.5, 0.5, 0.5}, Vec3[float64]{1, 1, 1}}, true},
+		{"containing", AABB[float64]{Vec3[float64]{-1, -1, -1}, Vec3[float64]{3, 3, 3}}, true},
+		{"touching a face", AABB[float64]{Vec3[float64]{2, 0, 0}, Vec3[float64]{3, 2, 2}}, true},
+		{"touching a corner", AABB[float64]{Vec3[float64]{2, 2, 2}, Vec3[float64]{3, 3, 3}}, true},
+		{"apart on x", AABB[float64]{Vec3[float64]{2.5, 0, 0}, Vec3[float64]{3, 2, 2}}, false},
+		{"apart on z only", AABB[float64]{Vec3[float64]{0, 0, -2}, Vec3[float64]{2, 2, -0.5}}, false},
+	}
+
+	for _, test := range tests {
+		if got := box.Intersects(test.other); got != test.want {
+			t.Errorf("%s: Intersects() = %t, want %t", test.name, got, test.want)
+		}
+
+		if got := test.other.Intersects(box); got != test.want {
+			t.Errorf("%s: reversed Intersects() = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestRayIntersectAABB(t *testing.T) {
+	box := AABB[float64]{Vec3[float64]{1, 1, 1}, Vec3[float64]{3, 3, 3}}
+
+	tests := []struct {
+		name string
+		ray  Ray[float64]
+		want float64
+		ok   bool
+	}{
+		{"straight on", Ray[float64]{Vec3[float64]{2, 2, -1}, Vec3[float64]{0, 0, 1}}, 2, true},
+		{"scaled direction", Ray[float64]{Vec3[float64]{2, 2, -1}, Vec3[float64]{0, 0, 2}}, 1, true},
+		{"diagonal", Ray[float64]{Vec3[float64]{0, 0, 0}, Vec3[float64]{1, 1, 1}}, 1, true},
+		{"from inside", Ray[float64]{Vec3[float64]{2, 2, 2}, Vec3[float64]{1, 0, 0}}, 0, true},
+		{"pointing away", Ray[float64]{Vec3[float64]{2, 2, 5}, Vec3[float64]{0, 0, 1}}, 0, false},
+		{"parallel outside", Ray[float64]{Vec3[float64]{0, 2, 0}, Vec3[float64]{0, 0, 1}}, 0, false},
+		{"passing by", Ray[float64]{Vec3[float64]{0, 5, 0}, Vec3[float64]{1, 0, 1}}, 0, false},
+	}
+
+	for _, test := range tests {
+		got, ok := test.ray.IntersectAABB(box)
+		if ok != test.ok || math.Abs(got-test.want) > tolerance {
+			t.Errorf("%s: IntersectAABB() = %v, %t, want %v, %t", test.name, got, ok, test.want, test.ok)
+		}
+
+		if ok && !box.Contains(test.ray.At(got)) {
+			t.Errorf("%s: At(%v) = %v is outside the box", test.name, got, test.ray.At(got))
+		}
+	}
+}
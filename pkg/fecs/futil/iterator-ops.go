@@ -0,0 +1,348 @@
+package futil
+
+// Pair holds two values of arbitrary types.
+type Pair[A, B interface{}] struct {
+	First  A
+	Second B
+}
+
+// Take returns an Iterator over at most the first n values of the given source
+// Iterator.
+func Take[T interface{}](it Iterator[T], n int) Iterator[T] {
+	return &takeIterator[T]{it, n}
+}
+
+type takeIterator[T interface{}] struct {
+	source    Iterator[T]
+	remaining int
+}
+
+func (t *takeIterator[T]) HasNext() bool {
+	return t.remaining > 0 && t.source.HasNext()
+}
+
+func (t *takeIterator[T]) Next() T {
+	if !t.HasNext() {
+		panic("no such element")
+	}
+
+	t.remaining--
+	return t.source.Next()
+}
+
+// Skip returns an Iterator over the values of the given source Iterator,
+// excluding the first n values.
+func Skip[T interface{}](it Iterator[T], n int) Iterator[T] {
+	return &skipIterator[T]{it, n}
+}
+
+type skipIterator[T interface{}] struct {
+	source Iterator[T]
+	skip   int
+}
+
+func (s *skipIterator[T]) HasNext() bool {
+	for ; s.skip > 0 && s.source.HasNext(); s.skip-- {
+		s.source.Next()
+	}
+
+	return s.source.HasNext()
+}
+
+func (s *skipIterator[T]) Next() T {
+	if !s.HasNext() {
+		panic("no such element")
+	}
+
+	return s.source.Next()
+}
+
+// TakeWhile returns an Iterator over the values of the given source Iterator up
+// to, but not including, the first value for which the given predicate returns
+// false.
+func TakeWhile[T interface{}](it Iterator[T], predicate func(T) bool) Iterator[T] {
+	return &takeWhileIterator[T]{source: it, predicate: predicate}
+}
+
+type takeWhileIterator[T interface{}] struct {
+	source    Iterator[T]
+	predicate func(T) bool
+	hasNext   bool
+	done      bool
+	next      T
+}
+
+func (t *takeWhileIterator[T]) HasNext() bool {
+	if t.hasNext {
+		return true
+	}
+
+	if t.done || !t.source.HasNext() {
+		return false
+	}
+
+	t.next = t.source.Next()
+
+	if !t.predicate(t.next) {
+		t.done = true
+		return false
+	}
+
+	t.hasNext = true
+	return true
+}
+
+func (t *takeWhileIterator[T]) Next() T {
+	if !t.HasNext() {
+		panic("no such element")
+	}
+
+	t.hasNext = false
+	return t.next
+}
+
+// Chain returns an Iterator over the values of each of the given Iterators in
+// turn.
+func Chain[T interface{}](its ...Iterator[T]) Iterator[T] {
+	return &chainIterator[T]{its}
+}
+
+type chainIterator[T interface{}] struct {
+	sources []Iterator[T]
+}
+
+func (c *chainIterator[T]) HasNext() bool {
+	for len(c.sources) > 0 {
+		if c.sources[0].HasNext() {
+			return true
+		}
+
+		c.sources = c.sources[1:]
+	}
+
+	return false
+}
+
+func (c *chainIterator[T]) Next() T {
+	if !c.HasNext() {
+		panic("no such element")
+	}
+
+	return c.sources[0].Next()
+}
+
+// Zip returns an Iterator over Pairs of values taken from the given Iterators in
+// lockstep.
+//
+// The returned Iterator ends as soon as either of the given Iterators ends.
+func Zip[A, B interface{}](a Iterator[A], b Iterator[B]) Iterator[Pair[A, B]] {
+	return &zipIterator[A, B]{a, b}
+}
+
+type zipIterator[A, B interface{}] struct {
+	a Iterator[A]
+	b Iterator[B]
+}
+
+func (z *zipIterator[A, B]) HasNext() bool {
+	return z.a.HasNext() && z.b.HasNext()
+}
+
+func (z *zipIterator[A, B]) Next() Pair[A, B] {
+	if !z.HasNext() {
+		panic("no such element")
+	}
+
+	return Pair[A, B]{z.a.Next(), z.b.Next()}
+}
+
+// Enumerate returns an Iterator over Pairs of the zero-based index of each
+// value of the given source Iterator and the value itself.
+func Enumerate[T interface{}](it Iterator[T]) Iterator[Pair[int, T]] {
+	return &enumerateIterator[T]{source: it}
+}
+
+type enumerateIterator[T interface{}] struct {
+	source Iterator[T]
+	index  int
+}
+
+func (e *enumerateIterator[T]) HasNext() bool {
+	return e.source.HasNext()
+}
+
+func (e *enumerateIterator[T]) Next() Pair[int, T] {
+	if !e.HasNext() {
+		panic("no such element")
+	}
+
+	e.index++
+	return Pair[int, T]{e.index - 1, e.source.Next()}
+}
+
+// FlatMap returns an Iterator over the values of each of the Iterators produced
+// by calling the given mapper on the values of the given source Iterator.
+func FlatMap[I, O interface{}](it Iterator[I], mapper func(I) Iterator[O]) Iterator[O] {
+	return &flatMapIterator[I, O]{source: it, mapper: mapper}
+}
+
+type flatMapIterator[I, O interface{}] struct {
+	source  Iterator[I]
+	mapper  func(I) Iterator[O]
+	current Iterator[O]
+}
+
+func (f *flatMapIterator[I, O]) HasNext() bool {
+	for f.current == nil || !f.current.HasNext() {
+		if !f.source.HasNext() {
+			return false
+		}
+
+		f.current = f.mapper(f.source.Next())
+	}
+
+	return true
+}
+
+func (f *flatMapIterator[I, O]) Next() O {
+	if !f.HasNext() {
+		panic("no such element")
+	}
+
+	return f.current.Next()
+}
+
+// Distinct returns an Iterator over the values of the given source Iterator,
+// skipping any value that has already been returned.
+//
+// The returned Iterator keeps a record of every value it has returned.
+func Distinct[T comparable](it Iterator[T]) Iterator[T] {
+	seen := make(map[T]bool, 16)
+
+	return NewFilteredIterator(it, func(value T) bool {
+		if seen[value] {
+			return false
+		}
+
+		seen[value] = true
+		return true
+	})
+}
+
+// Chunk returns an Iterator over slices of up to size consecutive values of the
+// given source Iterator.
+//
+// Every slice except possibly the last will contain exactly size values.
+//
+// If the given size is less than 1, this function will panic.
+func Chunk[T interface{}](it Iterator[T], size int) Iterator[[]T] {
+	if size < 1 {
+		panic("chunk size must be greater than zero")
+	}
+
+	return &chunkIterator[T]{it, size}
+}
+
+type chunkIterator[T interface{}] struct {
+	source Iterator[T]
+	size   int
+}
+
+func (c *chunkIterator[T]) HasNext() bool {
+	return c.source.HasNext()
+}
+
+func (c *chunkIterator[T]) Next() []T {
+	if !c.HasNext() {
+		panic("no such element")
+	}
+
+	out := make([]T, 0, c.size)
+
+	for len(out) < c.size && c.source.HasNext() {
+		out = append(out, c.source.Next())
+	}
+
+	return out
+}
+
+// Collect consumes the given Iterator, returning its values as a slice.
+func Collect[T interface{}](it Iterator[T]) []T {
+	out := make([]T, 0, 16)
+
+	for it.HasNext() {
+		out = append(out, it.Next())
+	}
+
+	return out
+}
+
+// Count consumes the given Iterator, returning the number of values it
+// produced.
+func Count[T interface{}](it Iterator[T]) int {
+	out := 0
+
+	for ; it.HasNext(); it.Next() {
+		out++
+	}
+
+	return out
+}
+
+// Reduce consumes the given Iterator, folding each of its values into an
+// accumulator starting with the given initial value.
+func Reduce[T, R interface{}](it Iterator[T], initial R, reducer func(acc R, value T) R) R {
+	out := initial
+
+	for it.HasNext() {
+		out = reducer(out, it.Next())
+	}
+
+	return out
+}
+
+// Any tests whether the given predicate returns true for any value of the given
+// Iterator.
+//
+// The Iterator is consumed up to and including the first matching value.
+func Any[T interface{}](it Iterator[T], predicate func(T) bool) bool {
+	for it.HasNext() {
+		if predicate(it.Next()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All tests whether the given predicate returns true for every value of the
+// given Iterator.
+//
+// The Iterator is consumed up to and including the first non-matching value.
+func All[T interface{}](it Iterator[T], predicate func(T) bool) bool {
+	for it.HasNext() {
+		if !predicate(it.Next()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// First returns the next value of the given Iterator, if it has one.
+func First[T interface{}](it Iterator[T]) (out T, found bool) {
+	if it.HasNext() {
+		out = it.Next()
+		found = true
+	}
+
+	return
+}
+
+// ForEach consumes the given Iterator, calling the given function on each of
+// its values.
+func ForEach[T interface{}](it Iterator[T], fn func(T)) {
+	for it.HasNext() {
+		fn(it.Next())
+	}
+}
@@ -0,0 +1,207 @@
+package futil
+
+import (
+	"slices"
+	"testing"
+)
+
+// countingIterator produces the values 0, 1, 2, ... up to, but not including,
+// end, recording how many values have been pulled from it.
+//
+// An end value less than 0 produces values forever.
+type countingIterator struct {
+	end    int
+	pulled int
+}
+
+func (c *countingIterator) HasNext() bool {
+	return c.end < 0 || c.pulled < c.end
+}
+
+func (c *countingIterator) Next() int {
+	if !c.HasNext() {
+		panic("no such element")
+	}
+
+	c.pulled++
+	return c.pulled - 1
+}
+
+func even(v int) bool { return v%2 == 0 }
+
+func TestIteratorCombinators(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(Iterator[int]) Iterator[int]
+		// input is the number of values produced by the source Iterator.
+		input int
+		want  []int
+	}{
+		{"Take", func(it Iterator[int]) Iterator[int] { return Take(it, 3) }, 5, []int{0, 1, 2}},
+		{"Take more than available", func(it Iterator[int]) Iterator[int] { return Take(it, 9) }, 2, []int{0, 1}},
+		{"Take zero", func(it Iterator[int]) Iterator[int] { return Take(it, 0) }, 5, nil},
+		{"Take from empty", func(it Iterator[int]) Iterator[int] { return Take(it, 3) }, 0, nil},
+		{"Skip", func(it Iterator[int]) Iterator[int] { return Skip(it, 3) }, 5, []int{3, 4}},
+		{"Skip everything", func(it Iterator[int]) Iterator[int] { return Skip(it, 9) }, 5, nil},
+		{"Skip from empty", func(it Iterator[int]) Iterator[int] { return Skip(it, 1) }, 0, nil},
+		{"TakeWhile", func(it Iterator[int]) Iterator[int] { return TakeWhile(it, func(v int) bool { return v < 3 }) }, 5, []int{0, 1, 2}},
+		{"TakeWhile none", func(it Iterator[int]) Iterator[int] { return TakeWhile(it, func(v int) bool { return v > 0 }) }, 5, nil},
+		{"TakeWhile from empty", func(it Iterator[int]) Iterator[int] { return TakeWhile(it, even) }, 0, nil},
+		{"Chain", func(it Iterator[int]) Iterator[int] { return Chain(it, Take[int](&countingIterator{end: -1}, 2)) }, 2, []int{0, 1, 0, 1}},
+		{"Chain empties", func(it Iterator[int]) Iterator[int] { return Chain(it, &countingIterator{}, it) }, 0, nil},
+		{"Chain nothing", func(Iterator[int]) Iterator[int] { return Chain[int]() }, 3, nil},
+		{"FlatMap", func(it Iterator[int]) Iterator[int] {
+			return FlatMap(it, func(v int) Iterator[int] { return &countingIterator{end: v} })
+		}, 4, []int{0, 0, 1, 0, 1, 2}},
+		{"FlatMap from empty", func(it Iterator[int]) Iterator[int] {
+			return FlatMap(it, func(v int) Iterator[int] { return &countingIterator{end: v} })
+		}, 0, nil},
+		{"Distinct", func(it Iterator[int]) Iterator[int] {
+			return Distinct(NewMappingIterator(it, func(v int) int { return v % 3 }))
+		}, 7, []int{0, 1, 2}},
+		{"Distinct from empty", func(it Iterator[int]) Iterator[int] { return Distinct(it) }, 0, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it := test.build(&countingIterator{end: test.input})
+
+			if got := Collect(it); !slices.Equal(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+
+			if it.HasNext() {
+				t.Errorf("HasNext() after draining")
+			}
+
+			mustPanic(t, "Next() after draining", func() { it.Next() })
+		})
+	}
+}
+
+func TestIteratorPairs(t *testing.T) {
+	zipped := Collect(Zip[int, int](&countingIterator{end: 3}, Skip[int](&countingIterator{end: -1}, 10)))
+	if want := []Pair[int, int]{{0, 10}, {1, 11}, {2, 12}}; !slices.Equal(zipped, want) {
+		t.Errorf("Zip() = %v, want %v", zipped, want)
+	}
+
+	if got := Count(Zip[int, int](&countingIterator{end: 3}, &countingIterator{})); got != 0 {
+		t.Errorf("Zip() with an empty side produced %d values", got)
+	}
+
+	enumerated := Collect(Enumerate(Skip[int](&countingIterator{end: 5}, 2)))
+	if want := []Pair[int, int]{{0, 2}, {1, 3}, {2, 4}}; !slices.Equal(enumerated, want) {
+		t.Errorf("Enumerate() = %v, want %v", enumerated, want)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		input, size int
+		want        [][]int
+	}{
+		{0, 2, nil},
+		{4, 2, [][]int{{0, 1}, {2, 3}}},
+		{5, 2, [][]int{{0, 1}, {2, 3}, {4}}},
+		{2, 5, [][]int{{0, 1}}},
+	}
+
+	for _, test := range tests {
+		got := Collect(Chunk[int](&countingIterator{end: test.input}, test.size))
+		if !slices.EqualFunc(got, test.want, slices.Equal) {
+			t.Errorf("Chunk(%d values, %d) = %v, want %v", test.input, test.size, got, test.want)
+		}
+	}
+
+	mustPanic(t, "Chunk(0)", func() { Chunk[int](&countingIterator{}, 0) })
+}
+
+// TestIteratorLaziness checks that combinators pull nothing from their source
+// until asked to, and no more than they need.
+func TestIteratorLaziness(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(Iterator[int]) Iterator[int]
+		take  int
+		// pulled is the number of values pulled from the source after take
+		// values have been read.
+		pulled int
+	}{
+		{"Take", func(it Iterator[int]) Iterator[int] { return Take(it, 3) }, 3, 3},
+		{"Skip", func(it Iterator[int]) Iterator[int] { return Skip(it, 3) }, 2, 5},
+		{"TakeWhile", func(it Iterator[int]) Iterator[int] { return TakeWhile(it, func(v int) bool { return v < 100 }) }, 2, 2},
+		{"Chain", func(it Iterator[int]) Iterator[int] { return Chain(it) }, 2, 2},
+		{"FlatMap", func(it Iterator[int]) Iterator[int] {
+			return FlatMap(it, func(v int) Iterator[int] { return Take[int](&countingIterator{end: -1}, v) })
+		}, 3, 3},
+		{"Distinct", func(it Iterator[int]) Iterator[int] { return Distinct(it) }, 2, 2},
+		{"filtered", func(it Iterator[int]) Iterator[int] { return NewFilteredIterator(it, even) }, 2, 3},
+		{"mapped", func(it Iterator[int]) Iterator[int] { return NewMappingIterator(it, func(v int) int { return -v }) }, 2, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := &countingIterator{end: -1}
+			it := test.build(source)
+
+			if source.pulled != 0 {
+				t.Fatalf("building pulled %d values", source.pulled)
+			}
+
+			for range test.take {
+				it.Next()
+			}
+
+			if source.pulled != test.pulled {
+				t.Errorf("reading %d values pulled %d, want %d", test.take, source.pulled, test.pulled)
+			}
+		})
+	}
+}
+
+// TestIteratorTerminals checks that terminal operations stop pulling from their
+// source as soon as they know their result.
+func TestIteratorTerminals(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  int
+		run    func(Iterator[int]) any
+		want   any
+		pulled int
+	}{
+		{"Any match", -1, func(it Iterator[int]) any { return Any(it, func(v int) bool { return v == 3 }) }, true, 4},
+		{"Any no match", 5, func(it Iterator[int]) any { return Any(it, func(v int) bool { return v > 10 }) }, false, 5},
+		{"Any empty", 0, func(it Iterator[int]) any { return Any(it, even) }, false, 0},
+		{"All failing", -1, func(it Iterator[int]) any { return All(it, func(v int) bool { return v < 2 }) }, false, 3},
+		{"All passing", 5, func(it Iterator[int]) any { return All(it, func(v int) bool { return v < 10 }) }, true, 5},
+		{"All empty", 0, func(it Iterator[int]) any { return All(it, even) }, true, 0},
+		{"First", -1, func(it Iterator[int]) any { v, ok := First(it); return Pair[int, bool]{v, ok} }, Pair[int, bool]{0, true}, 1},
+		{"First empty", 0, func(it Iterator[int]) any { v, ok := First(it); return Pair[int, bool]{v, ok} }, Pair[int, bool]{0, false}, 0},
+		{"Take then Count", -1, func(it Iterator[int]) any { return Count(Take(it, 4)) }, 4, 4},
+		{"Count empty", 0, func(it Iterator[int]) any { return Count(it) }, 0, 0},
+		{"Reduce", 5, func(it Iterator[int]) any { return Reduce(it, 100, func(a, v int) int { return a + v }) }, 110, 5},
+		{"Reduce empty", 0, func(it Iterator[int]) any {
+			return Reduce(it, "start", func(a string, _ int) string { return a + "!" })
+		}, "start", 0},
+		{"Collect empty", 0, func(it Iterator[int]) any { return len(Collect(it)) }, 0, 0},
+		{"ForEach", 4, func(it Iterator[int]) any {
+			var sum int
+			ForEach(it, func(v int) { sum += v })
+			return sum
+		}, 6, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := &countingIterator{end: test.input}
+
+			if got := test.run(source); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+
+			if source.pulled != test.pulled {
+				t.Errorf("pulled %d values, want %d", source.pulled, test.pulled)
+			}
+		})
+	}
+}
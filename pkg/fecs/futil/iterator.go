@@ -1,5 +1,7 @@
 package futil
 
+import "iter"
+
 type Iterable[T interface{}] interface {
 	Iterator() Iterator[T]
 }
@@ -77,3 +79,33 @@ func (m *mappingIterator[I, O]) HasNext() bool {
 func (m *mappingIterator[I, O]) Next() O {
 	return m.mapper(m.source.Next())
 }
+
+// Seq returns an iter.Seq that yields the remaining values of the given
+// Iterator.
+//
+// The given Iterator is consumed as the returned sequence is ranged over.  If
+// the range loop is exited early, the values not yet yielded remain in the
+// Iterator.
+func Seq[T interface{}](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it.HasNext() {
+			if !yield(it.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 returns an iter.Seq2 that yields the zero-based index of each of the
+// remaining values of the given Iterator, along with the value itself.
+//
+// The given Iterator is consumed as the returned sequence is ranged over.
+func Seq2[T interface{}](it Iterator[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; it.HasNext(); i++ {
+			if !yield(i, it.Next()) {
+				return
+			}
+		}
+	}
+}
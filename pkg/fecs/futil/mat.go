@@ -0,0 +1,293 @@
+package futil
+
+// Mat3 is a 3x3 matrix stored in row-major order.
+//
+// Vectors are treated as column vectors, meaning a matrix is applied to a
+// vector as M*v.
+type Mat3[T Numeric] [9]T
+
+// Mat4 is a 4x4 matrix stored in row-major order.
+//
+// Vectors are treated as column vectors, meaning a matrix is applied to a
+// vector as M*v.
+type Mat4[T Numeric] [16]T
+
+// Identity3 returns the 3x3 identity matrix.
+func Identity3[T Numeric]() Mat3[T] {
+	return Mat3[T]{
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1,
+	}
+}
+
+// Translation3 returns a 3x3 matrix that translates 2D points by the given
+// offset.
+func Translation3[T Numeric](offset Vec2[T]) Mat3[T] {
+	return Mat3[T]{
+		1, 0, offset[0],
+		0, 1, offset[1],
+		0, 0, 1,
+	}
+}
+
+// Scaling3 returns a 3x3 matrix that scales 2D points by the given factors.
+func Scaling3[T Numeric](factors Vec2[T]) Mat3[T] {
+	return Mat3[T]{
+		factors[0], 0, 0,
+		0, factors[1], 0,
+		0, 0, 1,
+	}
+}
+
+// At returns the value at the given row and column of this matrix.
+func (m Mat3[T]) At(row, col int) T {
+	return m[row*3+col]
+}
+
+// Mul returns the matrix product of this matrix and the given matrix.
+func (m Mat3[T]) Mul(o Mat3[T]) (out Mat3[T]) {
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			out[r*3+c] = m[r*3]*o[c] + m[r*3+1]*o[3+c] + m[r*3+2]*o[6+c]
+		}
+	}
+
+	return
+}
+
+// MulVec returns the product of this matrix and the given vector.
+func (m Mat3[T]) MulVec(v Vec3[T]) Vec3[T] {
+	return Vec3[T]{
+		m[0]*v[0] + m[1]*v[1] + m[2]*v[2],
+		m[3]*v[0] + m[4]*v[1] + m[5]*v[2],
+		m[6]*v[0] + m[7]*v[1] + m[8]*v[2],
+	}
+}
+
+// TransformPoint applies this matrix to the given 2D point, treating it as a
+// homogeneous coordinate with a w value of 1.
+func (m Mat3[T]) TransformPoint(p Vec2[T]) Vec2[T] {
+	v := m.MulVec(Vec3[T]{p[0], p[1], 1})
+	return Vec2[T]{v[0], v[1]}
+}
+
+// TransformDirection applies this matrix to the given 2D direction, ignoring
+// any translation.
+func (m Mat3[T]) TransformDirection(d Vec2[T]) Vec2[T] {
+	v := m.MulVec(Vec3[T]{d[0], d[1], 0})
+	return Vec2[T]{v[0], v[1]}
+}
+
+// Transpose returns the transpose of this matrix.
+func (m Mat3[T]) Transpose() Mat3[T] {
+	return Mat3[T]{
+		m[0], m[3], m[6],
+		m[1], m[4], m[7],
+		m[2], m[5], m[8],
+	}
+}
+
+// Determinant returns the determinant of this matrix.
+func (m Mat3[T]) Determinant() T {
+	return m[0]*(m[4]*m[8]-m[5]*m[7]) -
+		m[1]*(m[3]*m[8]-m[5]*m[6]) +
+		m[2]*(m[3]*m[7]-m[4]*m[6])
+}
+
+// Inverse returns the inverse of this matrix and true, or the zero matrix and
+// false if this matrix is not invertible.
+//
+// The inverse is calculated in float64 space before being converted back to T.
+func (m Mat3[T]) Inverse() (out Mat3[T], ok bool) {
+	var f [9]float64
+	for i := range m {
+		f[i] = float64(m[i])
+	}
+
+	det := f[0]*(f[4]*f[8]-f[5]*f[7]) -
+		f[1]*(f[3]*f[8]-f[5]*f[6]) +
+		f[2]*(f[3]*f[7]-f[4]*f[6])
+
+	if det == 0 {
+		return
+	}
+
+	inv := 1 / det
+	adj := [9]float64{
+		f[4]*f[8] - f[5]*f[7], f[2]*f[7] - f[1]*f[8], f[1]*f[5] - f[2]*f[4],
+		f[5]*f[6] - f[3]*f[8], f[0]*f[8] - f[2]*f[6], f[2]*f[3] - f[0]*f[5],
+		f[3]*f[7] - f[4]*f[6], f[1]*f[6] - f[0]*f[7], f[0]*f[4] - f[1]*f[3],
+	}
+
+	for i := range adj {
+		out[i] = T(adj[i] * inv)
+	}
+
+	return out, true
+}
+
+// Identity4 returns the 4x4 identity matrix.
+func Identity4[T Numeric]() Mat4[T] {
+	return Mat4[T]{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Translation4 returns a 4x4 matrix that translates 3D points by the given
+// offset.
+func Translation4[T Numeric](offset Vec3[T]) Mat4[T] {
+	return Mat4[T]{
+		1, 0, 0, offset[0],
+		0, 1, 0, offset[1],
+		0, 0, 1, offset[2],
+		0, 0, 0, 1,
+	}
+}
+
+// Scaling4 returns a 4x4 matrix that scales 3D points by the given factors.
+func Scaling4[T Numeric](factors Vec3[T]) Mat4[T] {
+	return Mat4[T]{
+		factors[0], 0, 0, 0,
+		0, factors[1], 0, 0,
+		0, 0, factors[2], 0,
+		0, 0, 0, 1,
+	}
+}
+
+// At returns the value at the given row and column of this matrix.
+func (m Mat4[T]) At(row, col int) T {
+	return m[row*4+col]
+}
+
+// Mul returns the matrix product of this matrix and the given matrix.
+func (m Mat4[T]) Mul(o Mat4[T]) (out Mat4[T]) {
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			out[r*4+c] = m[r*4]*o[c] + m[r*4+1]*o[4+c] + m[r*4+2]*o[8+c] + m[r*4+3]*o[12+c]
+		}
+	}
+
+	return
+}
+
+// MulVec returns the product of this matrix and the given vector.
+func (m Mat4[T]) MulVec(v Vec4[T]) (out Vec4[T]) {
+	for r := 0; r < 4; r++ {
+		out[r] = m[r*4]*v[0] + m[r*4+1]*v[1] + m[r*4+2]*v[2] + m[r*4+3]*v[3]
+	}
+
+	return
+}
+
+// TransformPoint applies this matrix to the given 3D point, treating it as a
+// homogeneous coordinate with a w value of 1.
+//
+// If the transformed w value is neither 0 nor 1, the result is divided by it.
+func (m Mat4[T]) TransformPoint(p Vec3[T]) Vec3[T] {
+	v := m.MulVec(Vec4[T]{p[0], p[1], p[2], 1})
+
+	if v[3] != 0 && v[3] != 1 {
+		return Vec3[T]{v[0] / v[3], v[1] / v[3], v[2] / v[3]}
+	}
+
+	return Vec3[T]{v[0], v[1], v[2]}
+}
+
+// TransformDirection applies this matrix to the given 3D direction, ignoring
+// any translation.
+func (m Mat4[T]) TransformDirection(d Vec3[T]) Vec3[T] {
+	v := m.MulVec(Vec4[T]{d[0], d[1], d[2], 0})
+	return Vec3[T]{v[0], v[1], v[2]}
+}
+
+// Transpose returns the transpose of this matrix.
+func (m Mat4[T]) Transpose() (out Mat4[T]) {
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			out[c*4+r] = m[r*4+c]
+		}
+	}
+
+	return
+}
+
+// Determinant returns the determinant of this matrix.
+func (m Mat4[T]) Determinant() T {
+	var f [16]float64
+	for i := range m {
+		f[i] = float64(m[i])
+	}
+
+	_, det := cofactors4(&f)
+	return T(det)
+}
+
+// Inverse returns the inverse of this matrix and true, or the zero matrix and
+// false if this matrix is not invertible.
+//
+// The inverse is calculated in float64 space before being converted back to T.
+func (m Mat4[T]) Inverse() (out Mat4[T], ok bool) {
+	var f [16]float64
+	for i := range m {
+		f[i] = float64(m[i])
+	}
+
+	adj, det := cofactors4(&f)
+	if det == 0 {
+		return
+	}
+
+	inv := 1 / det
+	for i := range adj {
+		out[i] = T(adj[i] * inv)
+	}
+
+	return out, true
+}
+
+// cofactors4 returns the adjugate and the determinant of the given 4x4 matrix.
+func cofactors4(m *[16]float64) (adj [16]float64, det float64) {
+	s0 := m[0]*m[5] - m[4]*m[1]
+	s1 := m[0]*m[6] - m[4]*m[2]
+	s2 := m[0]*m[7] - m[4]*m[3]
+	s3 := m[1]*m[6] - m[5]*m[2]
+	s4 := m[1]*m[7] - m[5]*m[3]
+	s5 := m[2]*m[7] - m[6]*m[3]
+
+	c5 := m[10]*m[15] - m[14]*m[11]
+	c4 := m[9]*m[15] - m[13]*m[11]
+	c3 := m[9]*m[14] - m[13]*m[10]
+	c2 := m[8]*m[15] - m[12]*m[11]
+	c1 := m[8]*m[14] - m[12]*m[10]
+	c0 := m[8]*m[13] - m[12]*m[9]
+
+	det = s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0
+
+	adj = [16]float64{
+		m[5]*c5 - m[6]*c4 + m[7]*c3,
+		-m[1]*c5 + m[2]*c4 - m[3]*c3,
+		m[13]*s5 - m[14]*s4 + m[15]*s3,
+		-m[9]*s5 + m[10]*s4 - m[11]*s3,
+
+		-m[4]*c5 + m[6]*c2 - m[7]*c1,
+		m[0]*c5 - m[2]*c2 + m[3]*c1,
+		-m[12]*s5 + m[14]*s2 - m[15]*s1,
+		m[8]*s5 - m[10]*s2 + m[11]*s1,
+
+		m[4]*c4 - m[5]*c2 + m[7]*c0,
+		-m[0]*c4 + m[1]*c2 - m[3]*c0,
+		m[12]*s4 - m[13]*s2 + m[15]*s0,
+		-m[8]*s4 + m[9]*s2 - m[11]*s0,
+
+		-m[4]*c3 + m[5]*c1 - m[6]*c0,
+		m[0]*c3 - m[1]*c1 + m[2]*c0,
+		-m[12]*s3 + m[13]*s1 - m[14]*s0,
+		m[8]*s3 - m[9]*s1 + m[10]*s0,
+	}
+
+	return
+}
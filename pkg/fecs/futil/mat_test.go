@@ -0,0 +1,151 @@
+package futil
+
+import "testing"
+
+func TestMat3Mul(t *testing.T) {
+	a := Mat3[int]{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	b := Mat3[int]{9, 8, 7, 6, 5, 4, 3, 2, 1}
+
+	if got, want := a.Mul(b), (Mat3[int]{30, 24, 18, 84, 69, 54, 138, 114, 90}); got != want {
+		t.Errorf("Mul() = %v, want %v", got, want)
+	}
+
+	if got := a.Mul(Identity3[int]()); got != a {
+		t.Errorf("Mul(Identity3()) = %v", got)
+	}
+
+	if got := a.Transpose(); got != (Mat3[int]{1, 4, 7, 2, 5, 8, 3, 6, 9}) {
+		t.Errorf("Transpose() = %v", got)
+	}
+
+	// Column vectors, so the right hand matrix applies first.
+	m := Translation3(Vec2[int]{10, 20}).Mul(Scaling3(Vec2[int]{2, 3}))
+
+	if got := m.TransformPoint(Vec2[int]{1, 1}); got != (Vec2[int]{12, 23}) {
+		t.Errorf("TransformPoint() = %v", got)
+	}
+
+	if got := m.TransformDirection(Vec2[int]{1, 1}); got != (Vec2[int]{2, 3}) {
+		t.Errorf("TransformDirection() = %v", got)
+	}
+}
+
+func TestMat3Inverse(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat3[float64]
+		want Mat3[float64]
+		det  float64
+		ok   bool
+	}{
+		{"identity", Identity3[float64](), Identity3[float64](), 1, true},
+		{"scaling", Scaling3(Vec2[float64]{2, 4}), Scaling3(Vec2[float64]{0.5, 0.25}), 8, true},
+		{"translation", Translation3(Vec2[float64]{3, -2}), Translation3(Vec2[float64]{-3, 2}), 1, true},
+		{"general", Mat3[float64]{1, 2, 3, 0, 1, 4, 5, 6, 0}, Mat3[float64]{-24, 18, 5, 20, -15, -4, -5, 4, 1}, 1, true},
+		{"singular", Mat3[float64]{1, 2, 3, 4, 5, 6, 7, 8, 9}, Mat3[float64]{}, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if det := test.m.Determinant(); det != test.det {
+				t.Errorf("Determinant() = %v, want %v", det, test.det)
+			}
+
+			got, ok := test.m.Inverse()
+			if ok != test.ok || !near(got, test.want) {
+				t.Fatalf("Inverse() = %v, %t, want %v, %t", got, ok, test.want, test.ok)
+			}
+
+			if ok && !near(test.m.Mul(got), Identity3[float64]()) {
+				t.Errorf("m * Inverse() = %v", test.m.Mul(got))
+			}
+		})
+	}
+}
+
+func TestMat4Mul(t *testing.T) {
+	m := Translation4(Vec3[float64]{1, 2, 3}).Mul(Scaling4(Vec3[float64]{2, 2, 2}))
+
+	want := Mat4[float64]{
+		2, 0, 0, 1,
+		0, 2, 0, 2,
+		0, 0, 2, 3,
+		0, 0, 0, 1,
+	}
+
+	if m != want {
+		t.Errorf("Mul() = %v, want %v", m, want)
+	}
+
+	if got := m.TransformPoint(Vec3[float64]{1, 1, 1}); got != (Vec3[float64]{3, 4, 5}) {
+		t.Errorf("TransformPoint() = %v", got)
+	}
+
+	if got := m.TransformDirection(Vec3[float64]{1, 1, 1}); got != (Vec3[float64]{2, 2, 2}) {
+		t.Errorf("TransformDirection() = %v", got)
+	}
+
+	if got := m.Transpose().Transpose(); got != m {
+		t.Errorf("Transpose().Transpose() = %v", got)
+	}
+
+	if m.At(1, 3) != 2 || m.Transpose().At(3, 1) != 2 {
+		t.Errorf("At(1, 3) = %v", m.At(1, 3))
+	}
+
+	// A w value other than 1 divides the result.
+	project := Mat4[float64]{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 2,
+	}
+
+	if got := project.TransformPoint(Vec3[float64]{2, 4, 6}); got != (Vec3[float64]{1, 2, 3}) {
+		t.Errorf("projecting TransformPoint() = %v", got)
+	}
+}
+
+func TestMat4Inverse(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat4[float64]
+		want Mat4[float64]
+		det  float64
+		ok   bool
+	}{
+		{"identity", Identity4[float64](), Identity4[float64](), 1, true},
+		{
+			"translate and scale",
+			Translation4(Vec3[float64]{1, 2, 3}).Mul(Scaling4(Vec3[float64]{2, 2, 2})),
+			Scaling4(Vec3[float64]{0.5, 0.5, 0.5}).Mul(Translation4(Vec3[float64]{-1, -2, -3})),
+			8,
+			true,
+		},
+		{
+			"general",
+			Mat4[float64]{1, 1, 1, -1, 1, 1, -1, 1, 1, -1, 1, 1, -1, 1, 1, 1},
+			Mat4[float64]{.25, .25, .25, -.25, .25, .25, -.25, .25, .25, -.25, .25, .25, -.25, .25, .25, .25},
+			-16,
+			true,
+		},
+		{"singular", Mat4[float64]{1, 2, 3, 4, 2, 4, 6, 8, 0, 1, 0, 1, 1, 0, 1, 0}, Mat4[float64]{}, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if det := test.m.Determinant(); det != test.det {
+				t.Errorf("Determinant() = %v, want %v", det, test.det)
+			}
+
+			got, ok := test.m.Inverse()
+			if ok != test.ok || !near(got, test.want) {
+				t.Fatalf("Inverse() = %v, %t, want %v, %t", got, ok, test.want, test.ok)
+			}
+
+			if ok && !near(got.Mul(test.m), Identity4[float64]()) {
+				t.Errorf("Inverse() * m = %v", got.Mul(test.m))
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package futil
+
+// PriorityQueue is a binary heap implementation over generic type T.
+//
+// Values are ordered by the comparison function the PriorityQueue was created
+// with; the value that sorts first is always at the top of the queue.
+type PriorityQueue[T interface{}] interface {
+
+	// Size returns the number of elements currently in the queue.
+	Size() int
+
+	// IsEmpty returns a boolean indicator for whether the queue is empty or not.
+	IsEmpty() bool
+
+	// Clear removes all elements from the queue.
+	Clear()
+
+	// Push adds a new value to the queue.
+	Push(value T)
+
+	// Pop removes and returns the top element from the queue.
+	//
+	// If the queue is empty, this method will panic.
+	Pop() T
+
+	// Peek returns the top element of the queue without removing it.
+	//
+	// If the queue is empty, this method will panic.
+	Peek() T
+}
+
+// NewPriorityQueue returns a new PriorityQueue instance that orders its values
+// using the given less function.
+//
+// The less function should return true if value a should be popped before value
+// b.
+func NewPriorityQueue[T interface{}](less func(a, b T) bool) PriorityQueue[T] {
+	return &priorityQueue[T]{
+		values: make([]T, 0, initialCapacity),
+		less:   less,
+	}
+}
+
+type priorityQueue[T interface{}] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (p *priorityQueue[T]) Size() int {
+	return len(p.values)
+}
+
+func (p *priorityQueue[T]) IsEmpty() bool {
+	return len(p.values) == 0
+}
+
+func (p *priorityQueue[T]) Clear() {
+	p.values = make([]T, 0, initialCapacity)
+}
+
+func (p *priorityQueue[T]) Push(value T) {
+	p.values = append(p.values, value)
+	p.up(len(p.values) - 1)
+}
+
+func (p *priorityQueue[T]) Pop() T {
+	if len(p.values) == 0 {
+		panic("attempted to pop an empty priority queue")
+	}
+
+	var zero T
+	last := len(p.values) - 1
+	out := p.values[0]
+
+	p.values[0] = p.values[last]
+	p.values[last] = zero
+	p.values = p.values[:last]
+
+	if last > 0 {
+		p.down(0)
+	}
+
+	return out
+}
+
+func (p *priorityQueue[T]) Peek() T {
+	if len(p.values) == 0 {
+		panic("no such element")
+	}
+
+	return p.values[0]
+}
+
+func (p *priorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+
+		if !p.less(p.values[i], p.values[parent]) {
+			return
+		}
+
+		p.values[i], p.values[parent] = p.values[parent], p.values[i]
+		i = parent
+	}
+}
+
+func (p *priorityQueue[T]) down(i int) {
+	n := len(p.values)
+
+	for {
+		top := i
+		left := 2*i + 1
+		right := left + 1
+
+		if left < n && p.less(p.values[left], p.values[top]) {
+			top = left
+		}
+		if right < n && p.less(p.values[right], p.values[top]) {
+			top = right
+		}
+
+		if top == i {
+			return
+		}
+
+		p.values[i], p.values[top] = p.values[top], p.values[i]
+		i = top
+	}
+}
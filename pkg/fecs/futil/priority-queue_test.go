@@ -0,0 +1,91 @@
+package futil
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	random := rand.New(rand.NewPCG(3, 4))
+
+	tests := []struct {
+		name string
+		// ops holds the values to push, with -1 meaning pop.
+		ops []int
+	}{
+		{"ascending", []int{1, 2, 3, 4, 5}},
+		{"descending", []int{5, 4, 3, 2, 1}},
+		{"duplicates", []int{2, 1, 2, 1, 2}},
+		{"pop between pushes", []int{5, 3, -1, 4, 1, -1, -1, 2, 0, -1}},
+		{"drain and refill", []int{3, 1, -1, -1, 2, -1, 9, 8}},
+		{"random", func() []int {
+			out := make([]int, 500)
+			for i := range out {
+				if random.IntN(3) == 0 {
+					out[i] = -1
+				} else {
+					out[i] = random.IntN(100)
+				}
+			}
+			return out
+		}()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewPriorityQueue(func(a, b int) bool { return a < b })
+
+			var model []int
+
+			for i, op := range test.ops {
+				if op >= 0 {
+					q.Push(op)
+					model = append(model, op)
+					slices.Sort(model)
+				} else if len(model) > 0 {
+					if got := q.Pop(); got != model[0] {
+						t.Fatalf("op %d: Pop() = %d, want %d", i, got, model[0])
+					}
+					model = model[1:]
+				}
+
+				if q.Size() != len(model) {
+					t.Fatalf("op %d: Size() = %d, want %d", i, q.Size(), len(model))
+				}
+
+				if len(model) > 0 && q.Peek() != model[0] {
+					t.Fatalf("op %d: Peek() = %d, want %d", i, q.Peek(), model[0])
+				}
+			}
+
+			for len(model) > 0 {
+				if got := q.Pop(); got != model[0] {
+					t.Fatalf("draining: Pop() = %d, want %d", got, model[0])
+				}
+				model = model[1:]
+			}
+
+			if !q.IsEmpty() {
+				t.Errorf("queue not empty after draining")
+			}
+		})
+	}
+}
+
+func TestPriorityQueueEmpty(t *testing.T) {
+	q := NewPriorityQueue(func(a, b string) bool { return a < b })
+
+	mustPanic(t, "Pop()", func() { q.Pop() })
+	mustPanic(t, "Peek()", func() { q.Peek() })
+
+	q.Push("b")
+	q.Push("a")
+	q.Clear()
+
+	if !q.IsEmpty() {
+		t.Errorf("Clear() left values behind")
+	}
+
+	mustPanic(t, "Pop() after Clear()", func() { q.Pop() })
+}
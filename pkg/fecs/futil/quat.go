@@ -0,0 +1,148 @@
+package futil
+
+import "math"
+
+// Quat is a quaternion stored as {x, y, z, w}, where w is the scalar part.
+type Quat[T Numeric] [4]T
+
+// IdentityQuat returns the quaternion representing no rotation.
+func IdentityQuat[T Numeric]() Quat[T] {
+	return Quat[T]{0, 0, 0, 1}
+}
+
+// QuatFromAxisAngle returns the quaternion representing a rotation of the given
+// angle, in radians, around the given axis.
+//
+// The given axis does not need to be normalized.
+func QuatFromAxisAngle[T Numeric](axis Vec3[T], angle float64) Quat[T] {
+	x, y, z := float64(axis[0]), float64(axis[1]), float64(axis[2])
+	l := math.Sqrt(x*x + y*y + z*z)
+
+	if l == 0 {
+		return IdentityQuat[T]()
+	}
+
+	s := math.Sin(angle/2) / l
+
+	return Quat[T]{T(x * s), T(y * s), T(z * s), T(math.Cos(angle / 2))}
+}
+
+// Mul returns the Hamilton product of this quaternion and the given quaternion,
+// representing the rotation of the given quaternion followed by the rotation of
+// this quaternion.
+func (q Quat[T]) Mul(o Quat[T]) Quat[T] {
+	return Quat[T]{
+		q[3]*o[0] + q[0]*o[3] + q[1]*o[2] - q[2]*o[1],
+		q[3]*o[1] - q[0]*o[2] + q[1]*o[3] + q[2]*o[0],
+		q[3]*o[2] + q[0]*o[1] - q[1]*o[0] + q[2]*o[3],
+		q[3]*o[3] - q[0]*o[0] - q[1]*o[1] - q[2]*o[2],
+	}
+}
+
+// Dot returns the dot product of this quaternion and the given quaternion.
+func (q Quat[T]) Dot(o Quat[T]) T {
+	return q[0]*o[0] + q[1]*o[1] + q[2]*o[2] + q[3]*o[3]
+}
+
+// Length returns the magnitude of this quaternion.
+func (q Quat[T]) Length() T {
+	return T(math.Sqrt(float64(q.Dot(q))))
+}
+
+// Normalize returns a unit length quaternion with the same orientation as this
+// quaternion.
+//
+// Normalizing a zero length quaternion returns the identity quaternion.
+func (q Quat[T]) Normalize() Quat[T] {
+	l := math.Sqrt(float64(q.Dot(q)))
+
+	if l == 0 {
+		return IdentityQuat[T]()
+	}
+
+	return Quat[T]{T(float64(q[0]) / l), T(float64(q[1]) / l), T(float64(q[2]) / l), T(float64(q[3]) / l)}
+}
+
+// Conjugate returns the conjugate of this quaternion.
+//
+// For unit quaternions, the conjugate represents the inverse rotation.
+func (q Quat[T]) Conjugate() Quat[T] {
+	return Quat[T]{-q[0], -q[1], -q[2], q[3]}
+}
+
+// Inverse returns the multiplicative inverse of this quaternion.
+//
+// The inverse of a zero length quaternion is the zero quaternion.
+func (q Quat[T]) Inverse() Quat[T] {
+	d := float64(q.Dot(q))
+
+	if d == 0 {
+		return Quat[T]{}
+	}
+
+	return Quat[T]{T(-float64(q[0]) / d), T(-float64(q[1]) / d), T(-float64(q[2]) / d), T(float64(q[3]) / d)}
+}
+
+// Rotate returns the given vector rotated by this quaternion.
+//
+// This quaternion is expected to be of unit length.
+func (q Quat[T]) Rotate(v Vec3[T]) Vec3[T] {
+	u := Vec3[T]{q[0], q[1], q[2]}
+	t := u.Cross(v).Scale(2)
+
+	return v.Add(t.Scale(q[3])).Add(u.Cross(t))
+}
+
+// Slerp spherically interpolates between this quaternion and the given
+// quaternion along the shortest path, where a t value of 0 returns this
+// quaternion and a t value of 1 returns the given quaternion.
+//
+// Both quaternions are expected to be of unit length.
+func (q Quat[T]) Slerp(o Quat[T], t float64) Quat[T] {
+	a := [4]float64{float64(q[0]), float64(q[1]), float64(q[2]), float64(q[3])}
+	b := [4]float64{float64(o[0]), float64(o[1]), float64(o[2]), float64(o[3])}
+
+	cos := a[0]*b[0] + a[1]*b[1] + a[2]*b[2] + a[3]*b[3]
+
+	// Take the short way around.
+	if cos < 0 {
+		cos = -cos
+		b = [4]float64{-b[0], -b[1], -b[2], -b[3]}
+	}
+
+	var wa, wb float64
+
+	// Fall back to linear interpolation when the quaternions are close enough
+	// that the sine of the angle between them approaches zero.
+	if cos > 0.9995 {
+		wa, wb = 1-t, t
+	} else {
+		theta := math.Acos(cos)
+		sin := math.Sin(theta)
+		wa = math.Sin((1-t)*theta) / sin
+		wb = math.Sin(t*theta) / sin
+	}
+
+	out := Quat[T]{
+		T(a[0]*wa + b[0]*wb),
+		T(a[1]*wa + b[1]*wb),
+		T(a[2]*wa + b[2]*wb),
+		T(a[3]*wa + b[3]*wb),
+	}
+
+	return out.Normalize()
+}
+
+// Mat4 returns the rotation matrix equivalent to this quaternion.
+//
+// This quaternion is expected to be of unit length.
+func (q Quat[T]) Mat4() Mat4[T] {
+	x, y, z, w := q[0], q[1], q[2], q[3]
+
+	return Mat4[T]{
+		1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y), 0,
+		2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x), 0,
+		2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y), 0,
+		0, 0, 0, 1,
+	}
+}
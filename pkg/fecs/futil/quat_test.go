@@ -0,0 +1,128 @@
+package futil
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuatRotate(t *testing.T) {
+	x, y, z := Vec3[float64]{1, 0, 0}, Vec3[float64]{0, 1, 0}, Vec3[float64]{0, 0, 1}
+
+	tests := []struct {
+		name  string
+		axis  Vec3[float64]
+		angle float64
+		v     Vec3[float64]
+		want  Vec3[float64]
+	}{
+		{"quarter turn around z", z, math.Pi / 2, x, y},
+		{"quarter turn around y", y, math.Pi / 2, x, z.Scale(-1)},
+		{"quarter turn around x", x, math.Pi / 2, y, z},
+		{"half turn around x", x, math.Pi, y, y.Scale(-1)},
+		{"vector on the axis", z, 1, z, z},
+		{"unnormalized axis", Vec3[float64]{0, 0, 5}, -math.Pi / 2, y, x},
+		{"third turn around the diagonal", Vec3[float64]{1, 1, 1}, 2 * math.Pi / 3, x, y},
+		{"zero axis", Vec3[float64]{}, 1, x, x},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := QuatFromAxisAngle(test.axis, test.angle)
+
+			if got := q.Rotate(test.v); !near(got, test.want) {
+				t.Errorf("Rotate() = %v, want %v", got, test.want)
+			}
+
+			if got := q.Mat4().TransformDirection(test.v); !near(got, test.want) {
+				t.Errorf("Mat4().TransformDirection() = %v, want %v", got, test.want)
+			}
+
+			if got := q.Conjugate().Rotate(test.want); !near(got, test.v) {
+				t.Errorf("Conjugate().Rotate() = %v, want %v", got, test.v)
+			}
+		})
+	}
+}
+
+func TestQuatMul(t *testing.T) {
+	z := Vec3[float64]{0, 0, 1}
+	eighth := QuatFromAxisAngle(z, math.Pi/4)
+
+	if got, want := eighth.Mul(eighth), QuatFromAxisAngle(z, math.Pi/2); !near(got, want) {
+		t.Errorf("Mul() = %v, want %v", got, want)
+	}
+
+	// The right hand rotation applies first.
+	x, y := Vec3[float64]{1, 0, 0}, Vec3[float64]{0, 1, 0}
+	aboutZ, aboutX := QuatFromAxisAngle(z, math.Pi/2), QuatFromAxisAngle(x, math.Pi/2)
+
+	if got := aboutX.Mul(aboutZ).Rotate(x); !near(got, z) {
+		t.Errorf("aboutX.Mul(aboutZ).Rotate(x) = %v, want %v", got, z)
+	}
+
+	if got := aboutZ.Mul(aboutX).Rotate(x); !near(got, y) {
+		t.Errorf("aboutZ.Mul(aboutX).Rotate(x) = %v, want %v", got, y)
+	}
+
+	q := Quat[float64]{1, 2, 2, 4}
+	if got := q.Mul(q.Inverse()); !near(got, IdentityQuat[float64]()) {
+		t.Errorf("Mul(Inverse()) = %v", got)
+	}
+
+	if got := (Quat[float64]{}).Inverse(); got != (Quat[float64]{}) {
+		t.Errorf("zero quaternion Inverse() = %v", got)
+	}
+}
+
+func TestQuatNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		q    Quat[float64]
+		want Quat[float64]
+	}{
+		{"unit", IdentityQuat[float64](), IdentityQuat[float64]()},
+		{"scaled", Quat[float64]{1, 2, 2, 4}, Quat[float64]{0.2, 0.4, 0.4, 0.8}},
+		{"negative", Quat[float64]{0, 0, -3, 0}, Quat[float64]{0, 0, -1, 0}},
+		{"zero", Quat[float64]{}, IdentityQuat[float64]()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.q.Normalize()
+
+			if !near(got, test.want) {
+				t.Errorf("Normalize() = %v, want %v", got, test.want)
+			}
+
+			if math.Abs(got.Length()-1) > tolerance {
+				t.Errorf("Normalize().Length() = %v", got.Length())
+			}
+		})
+	}
+}
+
+func TestQuatSlerp(t *testing.T) {
+	z := Vec3[float64]{0, 0, 1}
+	from, to := IdentityQuat[float64](), QuatFromAxisAngle(z, math.Pi/2)
+
+	tests := []struct {
+		t    float64
+		want Quat[float64]
+	}{
+		{0, from},
+		{0.5, QuatFromAxisAngle(z, math.Pi/4)},
+		{1, to},
+	}
+
+	for _, test := range tests {
+		if got := from.Slerp(to, test.t); !near(got, test.want) {
+			t.Errorf("Slerp(%v) = %v, want %v", test.t, got, test.want)
+		}
+	}
+
+	// q and -q are the same rotation, so the short way is taken.
+	negated := Quat[float64]{-to[0], -to[1], -to[2], -to[3]}
+	if got, want := from.Slerp(negated, 0.5), QuatFromAxisAngle(z, math.Pi/4); !near(got, want) {
+		t.Errorf("Slerp() towards a negated quaternion = %v, want %v", got, want)
+	}
+}
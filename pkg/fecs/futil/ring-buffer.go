@@ -0,0 +1,169 @@
+package futil
+
+// RingBuffer is a fixed-capacity buffer over generic type T.
+//
+// Once a RingBuffer is full, pushing a new value evicts the oldest value in the
+// buffer.
+type RingBuffer[T interface{}] interface {
+
+	// Size returns the number of elements currently in the buffer.
+	Size() int
+
+	// Capacity returns the maximum number of elements the buffer can hold.
+	Capacity() int
+
+	// IsEmpty returns a boolean indicator for whether the buffer is empty or not.
+	IsEmpty() bool
+
+	// IsFull returns a boolean indicator for whether the buffer is full or not.
+	IsFull() bool
+
+	// Clear removes all elements from the buffer.
+	Clear()
+
+	// Push adds a new value to the buffer as its newest element.
+	//
+	// If the buffer was already full, the oldest value is evicted and returned
+	// along with true.  Otherwise, the zero value of T and false are returned.
+	Push(value T) (T, bool)
+
+	// PopOldest removes and returns the oldest element in the buffer.
+	//
+	// If the buffer is empty, this method will panic.
+	PopOldest() T
+
+	// PopNewest removes and returns the newest element in the buffer.
+	//
+	// If the buffer is empty, this method will panic.
+	PopNewest() T
+
+	// PeekOldest returns the oldest element in the buffer without removing it.
+	//
+	// If the buffer is empty, this method will panic.
+	PeekOldest() T
+
+	// PeekNewest returns the newest element in the buffer without removing it.
+	//
+	// If the buffer is empty, this method will panic.
+	PeekNewest() T
+
+	// Get returns the value at the given index, where index 0 is the oldest
+	// element in the buffer.
+	//
+	// If the given index is less than zero or is greater than or equal to Size,
+	// this method will panic.
+	Get(index int) T
+
+	// Iterator returns a new Iterator instance over the values currently in the
+	// buffer, from oldest to newest.
+	Iterator() Iterator[T]
+}
+
+// NewRingBuffer returns a new RingBuffer instance with the given capacity.
+//
+// If the given capacity is less than 1, this function will panic.
+func NewRingBuffer[T interface{}](capacity int) RingBuffer[T] {
+	if capacity < 1 {
+		panic("ring buffer capacity must be greater than zero")
+	}
+
+	return &ringBuffer[T]{values: make([]T, capacity)}
+}
+
+type ringBuffer[T interface{}] struct {
+	values []T
+	head   int
+	size   int
+}
+
+func (r *ringBuffer[T]) Size() int {
+	return r.size
+}
+
+func (r *ringBuffer[T]) Capacity() int {
+	return len(r.values)
+}
+
+func (r *ringBuffer[T]) IsEmpty() bool {
+	return r.size == 0
+}
+
+func (r *ringBuffer[T]) IsFull() bool {
+	return r.size == len(r.values)
+}
+
+func (r *ringBuffer[T]) Clear() {
+	clear(r.values)
+	r.head = 0
+	r.size = 0
+}
+
+func (r *ringBuffer[T]) Push(value T) (evicted T, ok bool) {
+	if r.size == len(r.values) {
+		evicted = r.values[r.head]
+		ok = true
+		r.values[r.head] = value
+		r.head = (r.head + 1) % len(r.values)
+		return
+	}
+
+	r.values[(r.head+r.size)%len(r.values)] = value
+	r.size++
+	return
+}
+
+func (r *ringBuffer[T]) PopOldest() T {
+	if r.size == 0 {
+		panic("attempted to pop an empty ring buffer")
+	}
+
+	var zero T
+	out := r.values[r.head]
+	r.values[r.head] = zero
+	r.head = (r.head + 1) % len(r.values)
+	r.size--
+
+	return out
+}
+
+func (r *ringBuffer[T]) PopNewest() T {
+	if r.size == 0 {
+		panic("attempted to pop an empty ring buffer")
+	}
+
+	var zero T
+	idx := (r.head + r.size - 1) % len(r.values)
+	out := r.values[idx]
+	r.values[idx] = zero
+	r.size--
+
+	return out
+}
+
+func (r *ringBuffer[T]) PeekOldest() T {
+	if r.size == 0 {
+		panic("no such element")
+	}
+
+	return r.values[r.head]
+}
+
+func (r *ringBuffer[T]) PeekNewest() T {
+	if r.size == 0 {
+		panic("no such element")
+	}
+
+	return r.values[(r.head+r.size-1)%len(r.values)]
+}
+
+func (r *ringBuffer[T]) Get(index int) T {
+	if index < 0 || index >= r.size {
+		panic("no such element")
+	}
+
+	return r.values[(r.head+index)%len(r.values)]
+}
+
+func (r *ringBuffer[T]) Iterator() Iterator[T] {
+	return &indexIterator[T]{get: r.Get, size: r.size}
+}
@@ -0,0 +1,98 @@
+package futil
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingBuffer(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		push     int
+		popOld   int
+		popNew   int
+		want     []int
+		evicted  []int
+	}{
+		{"partly filled", 4, 3, 0, 0, []int{0, 1, 2}, nil},
+		{"exactly full", 4, 4, 0, 0, []int{0, 1, 2, 3}, nil},
+		{"wraps and evicts", 4, 7, 0, 0, []int{3, 4, 5, 6}, []int{0, 1, 2}},
+		{"wraps many times", 3, 10, 0, 0, []int{7, 8, 9}, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"pop oldest after wrapping", 4, 6, 2, 0, []int{4, 5}, []int{0, 1}},
+		{"pop newest after wrapping", 4, 6, 0, 3, []int{2}, []int{0, 1}},
+		{"capacity of one", 1, 3, 0, 0, []int{2}, []int{0, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRingBuffer[int](test.capacity)
+
+			var evicted []int
+			for i := range test.push {
+				if old, ok := r.Push(i); ok {
+					evicted = append(evicted, old)
+				}
+			}
+
+			if !slices.Equal(evicted, test.evicted) {
+				t.Errorf("evicted %v, want %v", evicted, test.evicted)
+			}
+
+			if r.IsFull() != (test.push >= test.capacity) {
+				t.Errorf("IsFull() = %t", r.IsFull())
+			}
+
+			for range test.popOld {
+				r.PopOldest()
+			}
+
+			for range test.popNew {
+				r.PopNewest()
+			}
+
+			if got := Collect(r.Iterator()); !slices.Equal(got, test.want) {
+				t.Errorf("contents = %v, want %v", got, test.want)
+			}
+
+			if r.Size() != len(test.want) || r.Capacity() != test.capacity {
+				t.Errorf("Size(), Capacity() = %d, %d, want %d, %d", r.Size(), r.Capacity(), len(test.want), test.capacity)
+			}
+
+			if r.PeekOldest() != test.want[0] || r.PeekNewest() != test.want[len(test.want)-1] {
+				t.Errorf("PeekOldest(), PeekNewest() = %d, %d", r.PeekOldest(), r.PeekNewest())
+			}
+
+			for i, want := range test.want {
+				if got := r.Get(i); got != want {
+					t.Errorf("Get(%d) = %d, want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRingBufferEmpty(t *testing.T) {
+	r := NewRingBuffer[int](2)
+
+	mustPanic(t, "PopOldest()", func() { r.PopOldest() })
+	mustPanic(t, "PopNewest()", func() { r.PopNewest() })
+	mustPanic(t, "PeekOldest()", func() { r.PeekOldest() })
+	mustPanic(t, "PeekNewest()", func() { r.PeekNewest() })
+	mustPanic(t, "Get(0)", func() { r.Get(0) })
+	mustPanic(t, "NewRingBuffer(0)", func() { NewRingBuffer[int](0) })
+
+	r.Push(1)
+	r.Push(2)
+	r.Push(3)
+	r.Clear()
+
+	if !r.IsEmpty() || r.IsFull() {
+		t.Errorf("Clear() left values behind")
+	}
+
+	r.Push(4)
+	if r.PeekOldest() != 4 || r.Size() != 1 {
+		t.Errorf("push after Clear() gave %v", Collect(r.Iterator()))
+	}
+}
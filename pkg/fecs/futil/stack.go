@@ -23,6 +23,10 @@ type Stack[T interface{}] interface {
 
 	// Pop removes and returns the top element from the stack.
 	Pop() T
+
+	// Copy returns a new, independent Stack containing the same elements as this
+	// stack, in the same order.
+	Copy() Stack[T]
 }
 
 // NewStack returns a new Stack instance.
@@ -52,7 +56,7 @@ func (s *stack[T]) Clear() {
 }
 
 func (s *stack[T]) Push(value T) {
-	s.ensureCapacity(s.index)
+	s.ensureCapacity(s.index + 1)
 	s.values[s.index] = value
 	s.index++
 }
@@ -62,22 +66,32 @@ func (s *stack[T]) Pop() T {
 		panic("attempted to pop an empty stack")
 	}
 	s.index--
+	out := s.values[s.index]
 	s.trim()
-	return s.values[s.index]
+	return out
+}
+
+func (s *stack[T]) Copy() Stack[T] {
+	tmp := make([]T, len(s.values))
+	copy(tmp, s.values[:s.index])
+
+	return &stack[T]{values: tmp, index: s.index}
 }
 
 func (s *stack[T]) ensureCapacity(size int) {
+	if len(s.values) >= size {
+		return
+	}
+
 	newSize := int(float32(len(s.values)) * stackGrowthFactor)
 
 	if newSize < size {
 		newSize = size
 	}
 
-	if cap(s.values) < size {
-		tmp := make([]T, size)
-		copy(tmp, s.values)
-		s.values = tmp
-	}
+	tmp := make([]T, newSize)
+	copy(tmp, s.values)
+	s.values = tmp
 }
 
 func (s *stack[T]) trim() {
@@ -2,10 +2,16 @@ package futil
 
 import "strconv"
 
+// ParseHexUint32 parses the given string as a base 16 uint32 value.
+func ParseHexUint32(value string) (uint32, error) {
+	out, err := strconv.ParseUint(value, 16, 32)
+	return uint32(out), err
+}
+
 func MustParseHexUint32(value string) uint32 {
-	if out, err := strconv.ParseUint(value, 16, 32); err != nil {
+	if out, err := ParseHexUint32(value); err != nil {
 		panic(err)
 	} else {
-		return uint32(out)
+		return out
 	}
 }
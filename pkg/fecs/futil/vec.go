@@ -0,0 +1,206 @@
+package futil
+
+import "math"
+
+// Add returns the component-wise sum of this vector and the given vector.
+func (v Vec2[T]) Add(o Vec2[T]) Vec2[T] {
+	return Vec2[T]{v[0] + o[0], v[1] + o[1]}
+}
+
+// Sub returns the component-wise difference of this vector and the given
+// vector.
+func (v Vec2[T]) Sub(o Vec2[T]) Vec2[T] {
+	return Vec2[T]{v[0] - o[0], v[1] - o[1]}
+}
+
+// Mul returns the component-wise product of this vector and the given vector.
+func (v Vec2[T]) Mul(o Vec2[T]) Vec2[T] {
+	return Vec2[T]{v[0] * o[0], v[1] * o[1]}
+}
+
+// Scale returns this vector with each component multiplied by the given value.
+func (v Vec2[T]) Scale(s T) Vec2[T] {
+	return Vec2[T]{v[0] * s, v[1] * s}
+}
+
+// Dot returns the dot product of this vector and the given vector.
+func (v Vec2[T]) Dot(o Vec2[T]) T {
+	return v[0]*o[0] + v[1]*o[1]
+}
+
+// Cross returns the z component of the cross product of this vector and the
+// given vector, treating both as 3D vectors lying on the XY plane.
+func (v Vec2[T]) Cross(o Vec2[T]) T {
+	return v[0]*o[1] - v[1]*o[0]
+}
+
+// LengthSquared returns the squared length of this vector.
+func (v Vec2[T]) LengthSquared() T {
+	return v.Dot(v)
+}
+
+// Length returns the length of this vector.
+func (v Vec2[T]) Length() T {
+	return T(math.Sqrt(float64(v.Dot(v))))
+}
+
+// Distance returns the distance between this vector and the given vector.
+func (v Vec2[T]) Distance(o Vec2[T]) T {
+	return v.Sub(o).Length()
+}
+
+// Normalize returns a unit length vector pointing in the same direction as this
+// vector.
+//
+// Normalizing a zero length vector returns a zero length vector.
+func (v Vec2[T]) Normalize() Vec2[T] {
+	l := math.Sqrt(float64(v.Dot(v)))
+
+	if l == 0 {
+		return v
+	}
+
+	return Vec2[T]{T(float64(v[0]) / l), T(float64(v[1]) / l)}
+}
+
+// Lerp linearly interpolates between this vector and the given vector, where a
+// t value of 0 returns this vector and a t value of 1 returns the given vector.
+func (v Vec2[T]) Lerp(o Vec2[T], t float64) Vec2[T] {
+	return Vec2[T]{lerp(v[0], o[0], t), lerp(v[1], o[1], t)}
+}
+
+// Add returns the component-wise sum of this vector and the given vector.
+func (v Vec3[T]) Add(o Vec3[T]) Vec3[T] {
+	return Vec3[T]{v[0] + o[0], v[1] + o[1], v[2] + o[2]}
+}
+
+// Sub returns the component-wise difference of this vector and the given
+// vector.
+func (v Vec3[T]) Sub(o Vec3[T]) Vec3[T] {
+	return Vec3[T]{v[0] - o[0], v[1] - o[1], v[2] - o[2]}
+}
+
+// Mul returns the component-wise product of this vector and the given vector.
+func (v Vec3[T]) Mul(o Vec3[T]) Vec3[T] {
+	return Vec3[T]{v[0] * o[0], v[1] * o[1], v[2] * o[2]}
+}
+
+// Scale returns this vector with each component multiplied by the given value.
+func (v Vec3[T]) Scale(s T) Vec3[T] {
+	return Vec3[T]{v[0] * s, v[1] * s, v[2] * s}
+}
+
+// Dot returns the dot product of this vector and the given vector.
+func (v Vec3[T]) Dot(o Vec3[T]) T {
+	return v[0]*o[0] + v[1]*o[1] + v[2]*o[2]
+}
+
+// Cross returns the cross product of this vector and the given vector.
+func (v Vec3[T]) Cross(o Vec3[T]) Vec3[T] {
+	return Vec3[T]{
+		v[1]*o[2] - v[2]*o[1],
+		v[2]*o[0] - v[0]*o[2],
+		v[0]*o[1] - v[1]*o[0],
+	}
+}
+
+// LengthSquared returns the squared length of this vector.
+func (v Vec3[T]) LengthSquared() T {
+	return v.Dot(v)
+}
+
+// Length returns the length of this vector.
+func (v Vec3[T]) Length() T {
+	return T(math.Sqrt(float64(v.Dot(v))))
+}
+
+// Distance returns the distance between this vector and the given vector.
+func (v Vec3[T]) Distance(o Vec3[T]) T {
+	return v.Sub(o).Length()
+}
+
+// Normalize returns a unit length vector pointing in the same direction as this
+// vector.
+//
+// Normalizing a zero length vector returns a zero length vector.
+func (v Vec3[T]) Normalize() Vec3[T] {
+	l := math.Sqrt(float64(v.Dot(v)))
+
+	if l == 0 {
+		return v
+	}
+
+	return Vec3[T]{T(float64(v[0]) / l), T(float64(v[1]) / l), T(float64(v[2]) / l)}
+}
+
+// Lerp linearly interpolates between this vector and the given vector, where a
+// t value of 0 returns this vector and a t value of 1 returns the given vector.
+func (v Vec3[T]) Lerp(o Vec3[T], t float64) Vec3[T] {
+	return Vec3[T]{lerp(v[0], o[0], t), lerp(v[1], o[1], t), lerp(v[2], o[2], t)}
+}
+
+// Add returns the component-wise sum of this vector and the given vector.
+func (v Vec4[T]) Add(o Vec4[T]) Vec4[T] {
+	return Vec4[T]{v[0] + o[0], v[1] + o[1], v[2] + o[2], v[3] + o[3]}
+}
+
+// Sub returns the component-wise difference of this vector and the given
+// vector.
+func (v Vec4[T]) Sub(o Vec4[T]) Vec4[T] {
+	return Vec4[T]{v[0] - o[0], v[1] - o[1], v[2] - o[2], v[3] - o[3]}
+}
+
+// Mul returns the component-wise product of this vector and the given vector.
+func (v Vec4[T]) Mul(o Vec4[T]) Vec4[T] {
+	return Vec4[T]{v[0] * o[0], v[1] * o[1], v[2] * o[2], v[3] * o[3]}
+}
+
+// Scale returns this vector with each component multiplied by the given value.
+func (v Vec4[T]) Scale(s T) Vec4[T] {
+	return Vec4[T]{v[0] * s, v[1] * s, v[2] * s, v[3] * s}
+}
+
+// Dot returns the dot product of this vector and the given vector.
+func (v Vec4[T]) Dot(o Vec4[T]) T {
+	return v[0]*o[0] + v[1]*o[1] + v[2]*o[2] + v[3]*o[3]
+}
+
+// LengthSquared returns the squared length of this vector.
+func (v Vec4[T]) LengthSquared() T {
+	return v.Dot(v)
+}
+
+// Length returns the length of this vector.
+func (v Vec4[T]) Length() T {
+	return T(math.Sqrt(float64(v.Dot(v))))
+}
+
+// Distance returns the distance between this vector and the given vector.
+func (v Vec4[T]) Distance(o Vec4[T]) T {
+	return v.Sub(o).Length()
+}
+
+// Normalize returns a unit length vector pointing in the same direction as this
+// vector.
+//
+// Normalizing a zero length vector returns a zero length vector.
+func (v Vec4[T]) Normalize() Vec4[T] {
+	l := math.Sqrt(float64(v.Dot(v)))
+
+	if l == 0 {
+		return v
+	}
+
+	return Vec4[T]{T(float64(v[0]) / l), T(float64(v[1]) / l), T(float64(v[2]) / l), T(float64(v[3]) / l)}
+}
+
+// Lerp linearly interpolates between this vector and the given vector, where a
+// t value of 0 returns this vector and a t value of 1 returns the given vector.
+func (v Vec4[T]) Lerp(o Vec4[T], t float64) Vec4[T] {
+	return Vec4[T]{lerp(v[0], o[0], t), lerp(v[1], o[1], t), lerp(v[2], o[2], t), lerp(v[3], o[3], t)}
+}
+
+// lerp linearly interpolates between the given values in float64 space.
+func lerp[T Numeric](a, b T, t float64) T {
+	return T(float64(a) + (float64(b)-float64(a))*t)
+}
@@ -0,0 +1,116 @@
+package futil
+
+import (
+	"math"
+	"testing"
+)
+
+// tolerance is the largest difference allowed between floating point values
+// that are expected to be equal.
+const tolerance = 1e-9
+
+// near tests whether each of the given values is within tolerance of the
+// matching wanted value.
+func near[T ~[2]float64 | ~[3]float64 | ~[4]float64 | ~[9]float64 | ~[16]float64](got, want T) bool {
+	for i := range len(got) {
+		if math.Abs(got[i]-want[i]) > tolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestVec2(t *testing.T) {
+	a, b := Vec2[float64]{3, 4}, Vec2[float64]{-1, 2}
+
+	if got := a.Add(b); got != (Vec2[float64]{2, 6}) {
+		t.Errorf("Add() = %v", got)
+	}
+
+	if got := a.Sub(b); got != (Vec2[float64]{4, 2}) {
+		t.Errorf("Sub() = %v", got)
+	}
+
+	if a.Dot(b) != 5 || a.Cross(b) != 10 || b.Cross(a) != -10 {
+		t.Errorf("Dot(), Cross() = %v, %v", a.Dot(b), a.Cross(b))
+	}
+
+	if a.Length() != 5 || a.LengthSquared() != 25 || a.Distance(b) != math.Sqrt(20) {
+		t.Errorf("Length(), LengthSquared(), Distance() = %v, %v, %v", a.Length(), a.LengthSquared(), a.Distance(b))
+	}
+
+	if got := a.Normalize(); !near(got, Vec2[float64]{0.6, 0.8}) {
+		t.Errorf("Normalize() = %v", got)
+	}
+
+	if got := (Vec2[float64]{}).Normalize(); got != (Vec2[float64]{}) {
+		t.Errorf("zero vector Normalize() = %v", got)
+	}
+
+	if got := a.Lerp(b, 0.25); !near(got, Vec2[float64]{2, 3.5}) {
+		t.Errorf("Lerp() = %v", got)
+	}
+}
+
+func TestVec3(t *testing.T) {
+	x, y, z := Vec3[float64]{1, 0, 0}, Vec3[float64]{0, 1, 0}, Vec3[float64]{0, 0, 1}
+
+	tests := []struct {
+		a, b, want Vec3[float64]
+	}{
+		{x, y, z},
+		{y, z, x},
+		{z, x, y},
+		{y, x, z.Scale(-1)},
+		{x, x, Vec3[float64]{}},
+		{Vec3[float64]{1, 2, 3}, Vec3[float64]{4, 5, 6}, Vec3[float64]{-3, 6, -3}},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Cross(test.b); got != test.want {
+			t.Errorf("%v.Cross(%v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+
+	v := Vec3[float64]{2, 3, 6}
+
+	if v.Length() != 7 || v.Dot(x) != 2 {
+		t.Errorf("Length(), Dot() = %v, %v", v.Length(), v.Dot(x))
+	}
+
+	if got := v.Normalize(); !near(got, Vec3[float64]{2.0 / 7, 3.0 / 7, 6.0 / 7}) {
+		t.Errorf("Normalize() = %v", got)
+	}
+
+	if got := v.Mul(Vec3[float64]{2, 0, -1}); got != (Vec3[float64]{4, 0, -6}) {
+		t.Errorf("Mul() = %v", got)
+	}
+}
+
+func TestVec4(t *testing.T) {
+	v := Vec4[float64]{1, 1, 1, 1}
+
+	if v.Length() != 2 || v.Distance(Vec4[float64]{}) != 2 {
+		t.Errorf("Length(), Distance() = %v, %v", v.Length(), v.Distance(Vec4[float64]{}))
+	}
+
+	if got := v.Normalize(); !near(got, Vec4[float64]{0.5, 0.5, 0.5, 0.5}) {
+		t.Errorf("Normalize() = %v", got)
+	}
+
+	if got := v.Lerp(Vec4[float64]{3, 5, 1, -1}, 0.5); got != (Vec4[float64]{2, 3, 1, 0}) {
+		t.Errorf("Lerp() = %v", got)
+	}
+}
+
+func TestVecIntegers(t *testing.T) {
+	// Integer vectors truncate rather than round.
+	if got := (Vec2[int]{3, 4}).Normalize(); got != (Vec2[int]{0, 0}) {
+		t.Errorf("Normalize() = %v", got)
+	}
+
+	if got := (Vec3[int]{0, 10, 20}).Lerp(Vec3[int]{10, 20, 30}, 0.5); got != (Vec3[int]{5, 15, 25}) {
+		t.Errorf("Lerp() = %v", got)
+	}
+}
@@ -0,0 +1,315 @@
+package fecs
+
+import "fmt"
+
+// NewJournal creates a new Journal and attaches it to the given Scene.
+//
+// From the moment this function returns, every structural change made to the
+// given Scene (new or destroyed entities, attached, removed or replaced
+// components) will be recorded by the returned Journal until it is detached.
+//
+// The given Scene must have been created by NewScene, otherwise this function
+// will panic.
+func NewJournal(s Scene) *Journal {
+	impl, ok := s.(*scene)
+	if !ok {
+		panic(fmt.Errorf("cannot attach a journal to scene %s, journals may only be attached to scenes created by NewScene", s.String()))
+	}
+
+	out := &Journal{scene: impl}
+	impl.addListener(out)
+
+	return out
+}
+
+// Journal records the changes made to a Scene as reversible operations,
+// grouped into named transactions, allowing those changes to be undone and
+// redone.
+//
+// Undoing or redoing a transaction restores the exact EntityID and ComponentID
+// values (including versions) that were in use when the transaction was
+// recorded.
+//
+// Component values are not copied by the Journal; only changes made by
+// replacing a Component via Scene.ReplaceComponent can be undone.  Changes made
+// by mutating a Component value in place will not be seen by the Journal.
+//
+// Making changes to the Scene while the Journal is detached will cause the
+// recorded history to fall out of sync with the Scene.  Attempting to undo or
+// redo an out of sync history will panic.
+type Journal struct {
+	scene     *scene
+	open      *journalTransaction
+	undo      []*journalTransaction
+	redo      []*journalTransaction
+	replaying bool
+}
+
+// Begin opens a new named transaction.
+//
+// All changes made to the Scene until Commit is called will be grouped into
+// the new transaction and undone or redone as a single unit.
+//
+// Changes made to the Scene while no transaction is open are recorded as
+// individual, single-operation transactions.
+//
+// If a transaction is already open, this method will panic.
+func (j *Journal) Begin(name string) {
+	if j.open != nil {
+		panic(fmt.Errorf("attempted to begin journal transaction %q while transaction %q is still open", name, j.open.name))
+	}
+
+	j.open = &journalTransaction{name: name}
+}
+
+// Commit closes the currently open transaction, adding it to the undo history.
+//
+// Transactions containing no changes are discarded.
+//
+// If no transaction is open, this method will panic.
+func (j *Journal) Commit() {
+	if j.open == nil {
+		panic("attempted to commit a journal transaction when no transaction was open")
+	}
+
+	tx := j.open
+	j.open = nil
+
+	if len(tx.ops) > 0 {
+		j.push(tx)
+	}
+}
+
+// Undo reverts the most recently recorded transaction, moving it onto the redo
+// history.
+//
+// If a transaction is currently open, this method will panic.
+//
+// Returns the name of the reverted transaction and true, or an empty string
+// and false if there was nothing to undo.
+func (j *Journal) Undo() (string, bool) {
+	j.requireClosed("undo")
+
+	if len(j.undo) == 0 {
+		return "", false
+	}
+
+	tx := j.undo[len(j.undo)-1]
+	j.undo = j.undo[:len(j.undo)-1]
+
+	j.replaying = true
+	defer func() { j.replaying = false }()
+
+	for i := len(tx.ops) - 1; i >= 0; i-- {
+		tx.ops[i].revert(j.scene)
+	}
+
+	j.redo = append(j.redo, tx)
+
+	return tx.name, true
+}
+
+// Redo re-applies the most recently undone transaction, moving it back onto the
+// undo history.
+//
+// Recording any new change to the Scene clears the redo history.
+//
+// If a transaction is currently open, this method will panic.
+//
+// Returns the name of the re-applied transaction and true, or an empty string
+// and false if there was nothing to redo.
+func (j *Journal) Redo() (string, bool) {
+	j.requireClosed("redo")
+
+	if len(j.redo) == 0 {
+		return "", false
+	}
+
+	tx := j.redo[len(j.redo)-1]
+	j.redo = j.redo[:len(j.redo)-1]
+
+	j.replaying = true
+	defer func() { j.replaying = false }()
+
+	for i := range tx.ops {
+		tx.ops[i].apply(j.scene)
+	}
+
+	j.undo = append(j.undo, tx)
+
+	return tx.name, true
+}
+
+// CanUndo tests whether there is a recorded transaction that may be undone.
+func (j *Journal) CanUndo() bool {
+	return len(j.undo) > 0
+}
+
+// CanRedo tests whether there is an undone transaction that may be redone.
+func (j *Journal) CanRedo() bool {
+	return len(j.redo) > 0
+}
+
+// Clear discards the recorded undo and redo histories, as well as any open
+// transaction.
+func (j *Journal) Clear() {
+	j.open = nil
+	j.undo = nil
+	j.redo = nil
+}
+
+// Detach stops this Journal from recording further changes to its Scene.
+func (j *Journal) Detach() {
+	j.scene.removeListener(j)
+}
+
+func (j *Journal) entityCreated(eid *EntityID) {
+	j.record(journalOp{kind: journalOpCreate, eid: *eid})
+}
+
+func (j *Journal) entityDestroyed(eid *EntityID, comps []componentRecord) {
+	j.record(journalOp{kind: journalOpDestroy, eid: *eid, comps: comps})
+}
+
+func (j *Journal) componentAttached(eid *EntityID, cid *ComponentID, comp Component) {
+	j.record(journalOp{kind: journalOpAttach, eid: *eid, cid: *cid, new: comp})
+}
+
+func (j *Journal) componentRemoved(eid *EntityID, cid *ComponentID, comp Component) {
+	j.record(journalOp{kind: journalOpRemove, eid: *eid, cid: *cid, old: comp})
+}
+
+func (j *Journal) componentReplaced(cid *ComponentID, old, new Component) {
+	j.record(journalOp{kind: journalOpReplace, cid: *cid, old: old, new: new})
+}
+
+func (j *Journal) sceneCompacted(*Remapping) {
+	// The recorded history refers to slots that may no longer exist.
+	j.Clear()
+}
+
+func (j *Journal) record(op journalOp) {
+	if j.replaying {
+		return
+	}
+
+	if j.open != nil {
+		j.open.ops = append(j.open.ops, op)
+	} else {
+		j.push(&journalTransaction{name: op.kind.String(), ops: []journalOp{op}})
+	}
+}
+
+func (j *Journal) push(tx *journalTransaction) {
+	j.undo = append(j.undo, tx)
+	j.redo = nil
+}
+
+func (j *Journal) requireClosed(action string) {
+	if j.open != nil {
+		panic(fmt.Errorf("attempted to %s while journal transaction %q is still open", action, j.open.name))
+	}
+}
+
+type journalTransaction struct {
+	name string
+	ops  []journalOp
+}
+
+type journalOpKind uint8
+
+const (
+	journalOpCreate journalOpKind = iota
+	journalOpDestroy
+	journalOpAttach
+	journalOpRemove
+	journalOpReplace
+)
+
+func (k journalOpKind) String() string {
+	switch k {
+	case journalOpCreate:
+		return "NewEntity"
+	case journalOpDestroy:
+		return "DestroyEntity"
+	case journalOpAttach:
+		return "AttachComponent"
+	case journalOpRemove:
+		return "RemoveComponent"
+	case journalOpReplace:
+		return "ReplaceComponent"
+	default:
+		panic("illegal state")
+	}
+}
+
+// journalOp is a single reversible change recorded by a Journal.
+type journalOp struct {
+	kind journalOpKind
+	eid  EntityID
+	cid  ComponentID
+	old  Component
+	new  Component
+
+	// comps holds the components that were attached to a destroyed entity.
+	comps []componentRecord
+}
+
+// apply (re)applies this operation to the given scene.
+func (o *journalOp) apply(s *scene) {
+	var ok bool
+
+	switch o.kind {
+	case journalOpCreate:
+		ok = s.restoreEntity(&o.eid)
+	case journalOpDestroy:
+		ok = s.DestroyEntity(&o.eid)
+	case journalOpAttach:
+		ok = s.restoreComponent(&o.eid, &o.cid, o.new)
+	case journalOpRemove:
+		ok = s.RemoveComponent(&o.eid, &o.cid)
+	case journalOpReplace:
+		ok = s.setComponent(&o.cid, o.new)
+	}
+
+	if !ok {
+		panic(fmt.Errorf("journal out of sync with scene %s, could not redo %s", s.String(), o.String()))
+	}
+}
+
+// revert undoes this operation on the given scene.
+func (o *journalOp) revert(s *scene) {
+	var ok bool
+
+	switch o.kind {
+	case journalOpCreate:
+		ok = s.DestroyEntity(&o.eid)
+	case journalOpDestroy:
+		ok = s.restoreEntity(&o.eid)
+
+		for i := 0; ok && i < len(o.comps); i++ {
+			ok = s.restoreComponent(&o.eid, &o.comps[i].id, o.comps[i].comp)
+		}
+	case journalOpAttach:
+		ok = s.RemoveComponent(&o.eid, &o.cid)
+	case journalOpRemove:
+		ok = s.restoreComponent(&o.eid, &o.cid, o.old)
+	case journalOpReplace:
+		ok = s.setComponent(&o.cid, o.old)
+	}
+
+	if !ok {
+		panic(fmt.Errorf("journal out of sync with scene %s, could not undo %s", s.String(), o.String()))
+	}
+}
+
+func (o *journalOp) String() string {
+	switch o.kind {
+	case journalOpCreate, journalOpDestroy:
+		return fmt.Sprintf("%s(%s)", o.kind, o.eid.String())
+	case journalOpReplace:
+		return fmt.Sprintf("%s(%s)", o.kind, o.cid.String())
+	default:
+		return fmt.Sprintf("%s(%s, %s)", o.kind, o.eid.String(), o.cid.String())
+	}
+}
@@ -0,0 +1,210 @@
+package fecs_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+// snapshotOf returns the JSON form of a Snapshot of the given Scene, which
+// records the exact IDs of every entity and Component.
+func snapshotOf(t *testing.T, scene fecs.Scene) string {
+	t.Helper()
+
+	snap, err := fecs.TakeSnapshot(scene)
+	mustNoErr(t, err)
+
+	buf := new(bytes.Buffer)
+	mustNoErr(t, snap.Write(buf))
+
+	return buf.String()
+}
+
+// mustPanic fails the test if the given function does not panic.
+func mustPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+
+	fn()
+}
+
+func TestJournalUndoRedo(t *testing.T) {
+	tests := []struct {
+		name   string
+		tx     string
+		change func(s fecs.Scene, j *fecs.Journal, ids fecstest.Entities)
+	}{
+		{"new entity", "NewEntity", func(s fecs.Scene, _ *fecs.Journal, _ fecstest.Entities) {
+			s.NewEntity()
+		}},
+		{"destroy entity", "DestroyEntity", func(s fecs.Scene, _ *fecs.Journal, ids fecstest.Entities) {
+			id := ids["player"]
+			s.DestroyEntity(&id)
+		}},
+		{"attach component", "AttachComponent", func(s fecs.Scene, _ *fecs.Journal, ids fecstest.Entities) {
+			id := ids["wall"]
+			s.AttachComponent(&id, newVelocity(0, 1))
+		}},
+		{"remove component", "RemoveComponent", func(s fecs.Scene, _ *fecs.Journal, ids fecstest.Entities) {
+			removeComponent(s, ids["player"], velocityType)
+		}},
+		{"replace component", "ReplaceComponent", func(s fecs.Scene, _ *fecs.Journal, ids fecstest.Entities) {
+			id := ids["player"]
+			for _, cid := range s.Components(&id) {
+				if cid.Type() == positionType {
+					s.ReplaceComponent(&cid, newPosition(50, 50))
+				}
+			}
+		}},
+		{"transaction", "spawn", func(s fecs.Scene, j *fecs.Journal, ids fecstest.Entities) {
+			j.Begin("spawn")
+			defer j.Commit()
+
+			id := s.NewEntity()
+			s.AttachComponent(&id, newPosition(3, 4))
+			s.AttachComponent(&id, newHealth(1))
+			removeComponent(s, id, healthType)
+
+			wall := ids["wall"]
+			s.DestroyEntity(&wall)
+		}},
+		{"reused slot", "respawn", func(s fecs.Scene, j *fecs.Journal, ids fecstest.Entities) {
+			j.Begin("respawn")
+			defer j.Commit()
+
+			id := ids["wall"]
+			s.DestroyEntity(&id)
+
+			id = s.NewEntity()
+			s.AttachComponent(&id, newFrozen)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene, ids := fecstest.NewScene(t, fecstest.Fixture{
+				"player": {&Position{1, 2}, &Velocity{1, 0}},
+				"wall":   {&Position{10, 0}, &Health{100}},
+			})
+
+			journal := fecs.NewJournal(scene)
+			before := snapshotOf(t, scene)
+
+			test.change(scene, journal, ids)
+			after := snapshotOf(t, scene)
+
+			if before == after {
+				t.Fatalf("change did not modify the scene")
+			}
+
+			for range 2 {
+				if name, ok := journal.Undo(); !ok || name != test.tx {
+					t.Errorf("Undo() = %q, %t, want %q, true", name, ok, test.tx)
+				}
+
+				if got := snapshotOf(t, scene); got != before {
+					t.Errorf("after Undo() scene is\n%s\nwant\n%s", got, before)
+				}
+
+				if name, ok := journal.Redo(); !ok || name != test.tx {
+					t.Errorf("Redo() = %q, %t, want %q, true", name, ok, test.tx)
+				}
+
+				if got := snapshotOf(t, scene); got != after {
+					t.Errorf("after Redo() scene is\n%s\nwant\n%s", got, after)
+				}
+			}
+
+			if journal.CanRedo() {
+				t.Errorf("CanRedo() after redoing everything")
+			}
+
+			if v := scene.Validate(); len(v) > 0 {
+				t.Errorf("Validate() = %v", v)
+			}
+		})
+	}
+}
+
+func TestJournalHistory(t *testing.T) {
+	scene := fecs.NewScene()
+	journal := fecs.NewJournal(scene)
+
+	if _, ok := journal.Undo(); ok {
+		t.Errorf("Undo() of an empty history succeeded")
+	}
+
+	if _, ok := journal.Redo(); ok {
+		t.Errorf("Redo() of an empty history succeeded")
+	}
+
+	journal.Begin("empty")
+	journal.Commit()
+
+	if journal.CanUndo() {
+		t.Errorf("an empty transaction was recorded")
+	}
+
+	first := scene.NewEntity()
+	scene.NewEntity()
+
+	journal.Undo()
+
+	if !journal.CanRedo() {
+		t.Fatalf("CanRedo() = false after Undo()")
+	}
+
+	scene.AttachComponent(&first, newFrozen)
+
+	if journal.CanRedo() {
+		t.Errorf("a new change did not clear the redo history")
+	}
+
+	scene.Compact()
+
+	if journal.CanUndo() || journal.CanRedo() {
+		t.Errorf("Compact() did not clear the history")
+	}
+
+	journal.Detach()
+	scene.NewEntity()
+
+	if journal.CanUndo() {
+		t.Errorf("a detached journal recorded a change")
+	}
+}
+
+func TestJournalPanics(t *testing.T) {
+	journal := fecs.NewJournal(fecs.NewScene())
+
+	mustPanic(t, "Commit() without a transaction", journal.Commit)
+
+	journal.Begin("open")
+
+	mustPanic(t, "Begin() inside a transaction", func() { journal.Begin("nested") })
+	mustPanic(t, "Undo() inside a transaction", func() { journal.Undo() })
+	mustPanic(t, "Redo() inside a transaction", func() { journal.Redo() })
+
+	journal.Clear()
+	journal.Begin("after clear")
+	journal.Commit()
+}
+
+func TestJournalOutOfSync(t *testing.T) {
+	scene := fecs.NewScene()
+	journal := fecs.NewJournal(scene)
+
+	id := scene.NewEntity()
+
+	journal.Detach()
+	scene.DestroyEntity(&id)
+
+	mustPanic(t, "Undo() of an out of sync history", func() { journal.Undo() })
+}
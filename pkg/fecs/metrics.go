@@ -0,0 +1,322 @@
+package fecs
+
+import (
+	"expvar"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// systemHistogramBuckets is the number of buckets in each System timing
+// histogram.
+//
+// Bucket upper bounds start at 1µs and double for each following bucket, with
+// the final bucket catching everything longer than ~1s.
+const systemHistogramBuckets = 22
+
+// NewMetrics creates a new Metrics instance that records operation counts for
+// and samples the contents of the given Scene.
+//
+// The given Scene must have been created by NewScene, otherwise this function
+// will panic.
+func NewMetrics(s Scene) *Metrics {
+	impl, ok := s.(*scene)
+	if !ok {
+		panic(fmt.Errorf("cannot collect metrics for scene %s, only scenes created by NewScene may be measured", s.String()))
+	}
+
+	out := &Metrics{
+		scene:   impl,
+		systems: make(map[string]*systemTimings, 8),
+	}
+
+	impl.addListener(out)
+	out.Sample()
+
+	return out
+}
+
+// Metrics collects performance and occupancy information about a Scene and the
+// Systems run against it.
+//
+// Metrics records:
+//   - per-System wall time histograms, via ObserveSystem or a SystemGroup
+//   - counts of the structural operations performed on the Scene
+//   - per-ComponentType component counts and pool occupancy
+//   - entity pool occupancy
+//   - counts of pool backing slice allocations
+//
+// Scene occupancy information is captured when Sample is called, which a
+// SystemGroup with these Metrics attached does at the end of each update.
+//
+// Sample must be called from the goroutine that owns the Scene, but Report may
+// be called from any goroutine.
+type Metrics struct {
+	scene *scene
+
+	lock    sync.Mutex
+	systems map[string]*systemTimings
+	ops     OperationMetrics
+	sample  sceneSample
+}
+
+// MetricsReport is a point-in-time copy of the values recorded by a Metrics
+// instance.
+type MetricsReport struct {
+	Scene       string             `json:"scene"`
+	Systems     []SystemMetrics    `json:"systems"`
+	Operations  OperationMetrics   `json:"operations"`
+	Entities    PoolMetrics        `json:"entities"`
+	Components  []ComponentMetrics `json:"components"`
+	Allocations uint64             `json:"allocations"`
+}
+
+// SystemMetrics holds the recorded wall times of a single System.
+type SystemMetrics struct {
+	Name    string            `json:"name"`
+	Count   uint64            `json:"count"`
+	Total   time.Duration     `json:"total"`
+	Min     time.Duration     `json:"min"`
+	Max     time.Duration     `json:"max"`
+	Buckets []HistogramBucket `json:"buckets"`
+}
+
+// Mean returns the average wall time of the System.
+func (s *SystemMetrics) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return s.Total / time.Duration(s.Count)
+}
+
+// HistogramBucket holds the number of observations that were less than or equal
+// to UpperBound, and greater than the UpperBound of the previous bucket.
+//
+// The final bucket of a histogram has an UpperBound of 0 and holds all the
+// observations greater than the UpperBound of the previous bucket.
+type HistogramBucket struct {
+	UpperBound time.Duration `json:"upperBound"`
+	Count      uint64        `json:"count"`
+}
+
+// OperationMetrics holds counts of the structural operations performed on a
+// Scene.
+type OperationMetrics struct {
+	EntitiesCreated    uint64 `json:"entitiesCreated"`
+	EntitiesDestroyed  uint64 `json:"entitiesDestroyed"`
+	ComponentsAttached uint64 `json:"componentsAttached"`
+	ComponentsRemoved  uint64 `json:"componentsRemoved"`
+	ComponentsReplaced uint64 `json:"componentsReplaced"`
+}
+
+// PoolMetrics describes the occupancy of a single pool.
+type PoolMetrics struct {
+	// Live is the number of values currently in use.
+	Live uint32 `json:"live"`
+
+	// Size is the number of pool slots that have ever been used.
+	Size uint32 `json:"size"`
+
+	// Capacity is the number of slots allocated for the pool.
+	Capacity uint32 `json:"capacity"`
+}
+
+func newPoolMetrics(stats PoolStats) PoolMetrics {
+	return PoolMetrics{
+		Live:     stats.Live,
+		Size:     stats.Size,
+		Capacity: stats.Capacity,
+	}
+}
+
+// ComponentMetrics describes the occupancy of the pool for a single
+// ComponentType.
+type ComponentMetrics struct {
+	Type ComponentType `json:"type"`
+	Name string        `json:"name"`
+	PoolMetrics
+}
+
+// ObserveSystem records a single run of the named System that took the given
+// wall time.
+func (m *Metrics) ObserveSystem(name string, elapsed time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	timings, ok := m.systems[name]
+	if !ok {
+		timings = &systemTimings{min: elapsed}
+		m.systems[name] = timings
+	}
+
+	timings.observe(elapsed)
+}
+
+// Sample captures the current entity and component occupancy of the Scene.
+func (m *Metrics) Sample() {
+	sample := sceneSample{
+		entities: PoolMetrics{
+			Live:     m.scene.entities.entityCount(),
+			Size:     m.scene.entities.size,
+			Capacity: uint32(len(m.scene.entities.pool)),
+		},
+		components: make([]ComponentMetrics, 0, len(m.scene.components)),
+		allocs:     uint64(m.scene.entities.allocs),
+	}
+
+	for ct, pool := range m.scene.components {
+		sample.components = append(sample.components, ComponentMetrics{
+			Type:        ct,
+			Name:        ct.Name(),
+			PoolMetrics: newPoolMetrics(pool.stats()),
+		})
+
+		sample.allocs += uint64(pool.allocations())
+	}
+
+	sort.Slice(sample.components, func(i, j int) bool { return sample.components[i].Type < sample.components[j].Type })
+
+	m.lock.Lock()
+	m.sample = sample
+	m.lock.Unlock()
+}
+
+// Report returns a copy of the values currently recorded by this Metrics
+// instance.
+func (m *Metrics) Report() MetricsReport {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	out := MetricsReport{
+		Scene:       m.scene.String(),
+		Systems:     make([]SystemMetrics, 0, len(m.systems)),
+		Operations:  m.ops,
+		Entities:    m.sample.entities,
+		Components:  append([]ComponentMetrics(nil), m.sample.components...),
+		Allocations: m.sample.allocs,
+	}
+
+	for name, timings := range m.systems {
+		out.Systems = append(out.Systems, timings.report(name))
+	}
+
+	sort.Slice(out.Systems, func(i, j int) bool { return out.Systems[i].Name < out.Systems[j].Name })
+
+	return out
+}
+
+// Reset clears the recorded System timings and operation counts.
+func (m *Metrics) Reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	clear(m.systems)
+	m.ops = OperationMetrics{}
+}
+
+// Publish publishes this Metrics instance's Report under the given name with
+// the expvar package.
+//
+// As with expvar.Publish, if the given name is already in use, this method will
+// panic.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return m.Report() }))
+}
+
+// Close stops this Metrics instance from counting operations performed on its
+// Scene.
+func (m *Metrics) Close() {
+	m.scene.removeListener(m)
+}
+
+func (m *Metrics) entityCreated(*EntityID) {
+	m.lock.Lock()
+	m.ops.EntitiesCreated++
+	m.lock.Unlock()
+}
+
+func (m *Metrics) entityDestroyed(*EntityID, []componentRecord) {
+	m.lock.Lock()
+	m.ops.EntitiesDestroyed++
+	m.lock.Unlock()
+}
+
+func (m *Metrics) componentAttached(*EntityID, *ComponentID, Component) {
+	m.lock.Lock()
+	m.ops.ComponentsAttached++
+	m.lock.Unlock()
+}
+
+func (m *Metrics) componentRemoved(*EntityID, *ComponentID, Component) {
+	m.lock.Lock()
+	m.ops.ComponentsRemoved++
+	m.lock.Unlock()
+}
+
+func (m *Metrics) componentReplaced(*ComponentID, Component, Component) {
+	m.lock.Lock()
+	m.ops.ComponentsReplaced++
+	m.lock.Unlock()
+}
+
+func (m *Metrics) sceneCompacted(*Remapping) {
+	// Pool occupancy is picked up by the next call to Sample.
+}
+
+type sceneSample struct {
+	entities   PoolMetrics
+	components []ComponentMetrics
+	allocs     uint64
+}
+
+type systemTimings struct {
+	count   uint64
+	total   time.Duration
+	min     time.Duration
+	max     time.Duration
+	buckets [systemHistogramBuckets]uint64
+}
+
+func (s *systemTimings) observe(elapsed time.Duration) {
+	s.count++
+	s.total += elapsed
+	s.min = min(s.min, elapsed)
+	s.max = max(s.max, elapsed)
+
+	bound := time.Microsecond
+	for i := 0; i < systemHistogramBuckets-1; i++ {
+		if elapsed <= bound {
+			s.buckets[i]++
+			return
+		}
+
+		bound *= 2
+	}
+
+	s.buckets[systemHistogramBuckets-1]++
+}
+
+func (s *systemTimings) report(name string) SystemMetrics {
+	out := SystemMetrics{
+		Name:    name,
+		Count:   s.count,
+		Total:   s.total,
+		Min:     s.min,
+		Max:     s.max,
+		Buckets: make([]HistogramBucket, systemHistogramBuckets),
+	}
+
+	bound := time.Microsecond
+	for i := range out.Buckets {
+		out.Buckets[i].Count = s.buckets[i]
+
+		if i < systemHistogramBuckets-1 {
+			out.Buckets[i].UpperBound = bound
+			bound *= 2
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,209 @@
+package fecs_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func TestMetricsOperations(t *testing.T) {
+	scene := fecs.NewScene()
+	metrics := fecs.NewMetrics(scene)
+
+	a := scene.NewEntity()
+	b := scene.NewEntity()
+	pos := scene.AttachComponent(&a, newPosition(0, 0))
+	scene.AttachComponent(&a, newVelocity(1, 0))
+	scene.AttachComponent(&b, newPosition(2, 0))
+	scene.ReplaceComponent(&pos, newPosition(3, 0))
+	removeComponent(scene, a, velocityType)
+	scene.SpawnBatch(3, newPosition(0, 0), newHealth(10))
+	scene.DestroyEntity(&b)
+
+	// Failed operations are not counted.
+	scene.DestroyEntity(&b)
+	scene.RemoveComponent(&b, &pos)
+
+	want := fecs.OperationMetrics{
+		EntitiesCreated:    5,
+		EntitiesDestroyed:  1,
+		ComponentsAttached: 9,
+		ComponentsRemoved:  1,
+		ComponentsReplaced: 1,
+	}
+
+	if got := metrics.Report().Operations; got != want {
+		t.Errorf("Operations = %+v, want %+v", got, want)
+	}
+
+	metrics.Reset()
+	scene.NewEntity()
+
+	if got := metrics.Report().Operations; got != (fecs.OperationMetrics{EntitiesCreated: 1}) {
+		t.Errorf("Operations after Reset() = %+v", got)
+	}
+
+	metrics.Close()
+	scene.NewEntity()
+
+	if got := metrics.Report().Operations.EntitiesCreated; got != 1 {
+		t.Errorf("EntitiesCreated after Close() = %d, want 1", got)
+	}
+}
+
+func TestMetricsSample(t *testing.T) {
+	scene := fecs.NewScene()
+	metrics := fecs.NewMetrics(scene)
+
+	if report := metrics.Report(); report.Entities.Live != 0 || len(report.Components) != 0 {
+		t.Errorf("empty scene sampled as %+v", report)
+	}
+
+	ids := scene.SpawnBatch(4, newPosition(0, 0))
+	scene.AttachComponent(&ids[0], newVelocity(0, 0))
+	scene.DestroyEntity(&ids[1])
+
+	// Nothing changes until the next sample.
+	if got := metrics.Report().Entities.Live; got != 0 {
+		t.Errorf("Entities.Live before Sample() = %d, want 0", got)
+	}
+
+	metrics.Sample()
+	report := metrics.Report()
+
+	if report.Entities.Live != 3 || report.Entities.Size != 4 || report.Entities.Capacity < 4 {
+		t.Errorf("Entities = %+v, want 3 live of 4", report.Entities)
+	}
+
+	if len(report.Components) != 2 {
+		t.Fatalf("Components = %+v, want 2 types", report.Components)
+	}
+
+	// Components are ordered by type.
+	first, second := report.Components[0], report.Components[1]
+	if first.Type > second.Type {
+		t.Errorf("Components out of order: %s before %s", first.Name, second.Name)
+	}
+
+	for _, c := range report.Components {
+		var live, size uint32
+		switch c.Type {
+		case positionType:
+			live, size = 3, 4
+		case velocityType:
+			live, size = 1, 1
+		}
+
+		if c.Name != c.Type.Name() || c.Live != live || c.Size != size {
+			t.Errorf("%s = %+v, want %d live of %d", c.Type.Name(), c, live, size)
+		}
+	}
+
+	if report.Allocations == 0 {
+		t.Errorf("Allocations = 0")
+	}
+
+	// Reset keeps the sample.
+	metrics.Reset()
+	if got := metrics.Report().Entities.Live; got != 3 {
+		t.Errorf("Entities.Live after Reset() = %d, want 3", got)
+	}
+}
+
+func TestMetricsHistogram(t *testing.T) {
+	metrics := fecs.NewMetrics(fecs.NewScene())
+
+	observations := []struct {
+		elapsed time.Duration
+		bucket  int
+	}{
+		{0, 0},
+		{time.Microsecond, 0},
+		{time.Microsecond + 1, 1},
+		{2 * time.Microsecond, 1},
+		{3 * time.Microsecond, 2},
+		{time.Millisecond, 10},
+		{1 << 20 * time.Microsecond, 20},
+		{1<<20*time.Microsecond + 1, 21},
+		{time.Hour, 21},
+	}
+
+	want := make([]uint64, 22)
+	var total time.Duration
+
+	for _, o := range observations {
+		metrics.ObserveSystem("physics", o.elapsed)
+		want[o.bucket]++
+		total += o.elapsed
+	}
+
+	metrics.ObserveSystem("ai", 5*time.Microsecond)
+
+	report := metrics.Report()
+	if len(report.Systems) != 2 || report.Systems[0].Name != "ai" || report.Systems[1].Name != "physics" {
+		t.Fatalf("Systems = %+v, want ai then physics", report.Systems)
+	}
+
+	physics := report.Systems[1]
+
+	if physics.Count != uint64(len(observations)) || physics.Total != total || physics.Min != 0 || physics.Max != time.Hour {
+		t.Errorf("physics = count %d, total %s, min %s, max %s", physics.Count, physics.Total, physics.Min, physics.Max)
+	}
+
+	if physics.Mean() != total/time.Duration(len(observations)) {
+		t.Errorf("Mean() = %s", physics.Mean())
+	}
+
+	if len(physics.Buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(physics.Buckets), len(want))
+	}
+
+	bound := time.Microsecond
+	for i, bucket := range physics.Buckets {
+		wantBound := bound
+		if i == len(want)-1 {
+			wantBound = 0
+		}
+
+		if bucket.UpperBound != wantBound || bucket.Count != want[i] {
+			t.Errorf("bucket %d = %+v, want upper bound %s and count %d", i, bucket, wantBound, want[i])
+		}
+
+		bound *= 2
+	}
+
+	if ai := report.Systems[0]; ai.Min != 5*time.Microsecond || ai.Buckets[3].Count != 1 {
+		t.Errorf("ai = %+v", ai)
+	}
+
+	if (&fecs.SystemMetrics{}).Mean() != 0 {
+		t.Errorf("Mean() of an unobserved System is not 0")
+	}
+}
+
+func TestMetricsPublish(t *testing.T) {
+	scene := fecs.NewScene()
+	metrics := fecs.NewMetrics(scene)
+	metrics.Publish("fecs-test-metrics")
+
+	scene.NewEntity()
+	metrics.ObserveSystem("physics", time.Millisecond)
+
+	var report fecs.MetricsReport
+	if err := json.Unmarshal([]byte(expvar.Get("fecs-test-metrics").String()), &report); err != nil {
+		t.Fatalf("published metrics are not valid JSON: %s", err)
+	}
+
+	if report.Scene != scene.String() || report.Operations.EntitiesCreated != 1 {
+		t.Errorf("published report = %+v", report)
+	}
+
+	if len(report.Systems) != 1 || report.Systems[0].Total != time.Millisecond {
+		t.Errorf("published Systems = %+v", report.Systems)
+	}
+
+	mustPanic(t, "publishing the same name twice", func() { metrics.Publish("fecs-test-metrics") })
+}
@@ -0,0 +1,198 @@
+package fecs
+
+import "fmt"
+
+// ObserverEventKind indicates whether an ObserverEvent describes an entity
+// starting or stopping matching an Observer's filter.
+type ObserverEventKind uint8
+
+const (
+	// ObserverEnter indicates that an entity started matching an Observer's
+	// filter.
+	ObserverEnter ObserverEventKind = iota + 1
+
+	// ObserverExit indicates that an entity stopped matching an Observer's
+	// filter.
+	ObserverExit
+)
+
+func (k ObserverEventKind) String() string {
+	switch k {
+	case ObserverEnter:
+		return "enter"
+	case ObserverExit:
+		return "exit"
+	default:
+		return "unknown"
+	}
+}
+
+// ObserverEvent records a single transition of an entity into or out of an
+// Observer's filter.
+type ObserverEvent struct {
+	Kind   ObserverEventKind
+	Entity EntityID
+}
+
+// ObserverCallback defines a function that is called when an entity enters or
+// exits an Observer's filter.
+type ObserverCallback = func(scene Scene, id EntityID)
+
+// NewObserver creates a new Observer that watches for entities in the given
+// Scene starting or stopping having Components of all the given ComponentTypes
+// attached.
+//
+// If no ComponentTypes are given, every entity matches the filter, meaning
+// entities enter when they are created and exit when they are destroyed.
+//
+// Entities that already match the filter when the Observer is created do not
+// produce an enter event.
+//
+// The given Scene must have been created by NewScene, otherwise this function
+// will panic.
+func NewObserver(s Scene, types ...ComponentType) *Observer {
+	impl, ok := s.(*scene)
+	if !ok {
+		panic(fmt.Errorf("cannot observe scene %s, only scenes created by NewScene may be observed", s.String()))
+	}
+
+	out := &Observer{scene: impl}
+	for _, ct := range types {
+		out.filter.add(ct)
+	}
+
+	impl.addListener(out)
+
+	return out
+}
+
+// Observer watches a Scene for entities whose set of attached ComponentTypes
+// transitions into or out of a filter.
+//
+// Transitions may be received either through callbacks registered with OnEnter
+// and OnExit, which are called synchronously as the change is made, or by
+// enabling buffering and periodically draining the recorded events with Events.
+//
+// Callbacks should not make structural changes to the Scene; Observers that
+// need to react to a transition with further changes should use buffering
+// instead.
+type Observer struct {
+	scene    *scene
+	filter   componentMask
+	onEnter  ObserverCallback
+	onExit   ObserverCallback
+	buffered bool
+	events   []ObserverEvent
+}
+
+// OnEnter sets the function that will be called when an entity starts matching
+// this Observer's filter.
+func (o *Observer) OnEnter(fn ObserverCallback) {
+	o.onEnter = fn
+}
+
+// OnExit sets the function that will be called when an entity stops matching
+// this Observer's filter.
+func (o *Observer) OnExit(fn ObserverCallback) {
+	o.onExit = fn
+}
+
+// SetBuffered enables or disables the recording of events for retrieval with
+// Events.
+//
+// Disabling buffering discards any events that have not yet been retrieved.
+func (o *Observer) SetBuffered(buffered bool) {
+	o.buffered = buffered
+
+	if !buffered {
+		o.events = nil
+	}
+}
+
+// Events returns the events recorded since the previous call to Events, in the
+// order in which they occurred, and clears the event buffer.
+//
+// Events recorded before a call to Scene.Compact refer to the entities' new
+// EntityIDs.  Exit events for destroyed entities keep the destroyed EntityID.
+//
+// If buffering has not been enabled with SetBuffered, this method returns nil.
+func (o *Observer) Events() []ObserverEvent {
+	out := o.events
+	o.events = nil
+	return out
+}
+
+// Matches tests whether the entity identified by the given EntityID currently
+// matches this Observer's filter.
+func (o *Observer) Matches(id *EntityID) bool {
+	return o.scene.entities.containsEntity(id) && o.scene.entities.pool[id.index].mask.hasAll(&o.filter)
+}
+
+// Close stops this Observer from watching its Scene.
+func (o *Observer) Close() {
+	o.scene.removeListener(o)
+}
+
+func (o *Observer) entityCreated(eid *EntityID) {
+	// A newly created entity has no components, so it can only match an empty
+	// filter.
+	if o.filter.isEmpty() {
+		o.emit(ObserverEnter, eid)
+	}
+}
+
+func (o *Observer) entityDestroyed(eid *EntityID, comps []componentRecord) {
+	var mask componentMask
+	for i := range comps {
+		mask.add(comps[i].id.ctype)
+	}
+
+	if mask.hasAll(&o.filter) {
+		o.emit(ObserverExit, eid)
+	}
+}
+
+func (o *Observer) componentAttached(eid *EntityID, cid *ComponentID, _ Component) {
+	// The entity could only have started matching if the new component is part
+	// of the filter.
+	if o.filter.has(cid.ctype) && o.scene.entities.pool[eid.index].mask.hasAll(&o.filter) {
+		o.emit(ObserverEnter, eid)
+	}
+}
+
+func (o *Observer) componentRemoved(eid *EntityID, cid *ComponentID, _ Component) {
+	if !o.filter.has(cid.ctype) {
+		return
+	}
+
+	mask := o.scene.entities.pool[eid.index].mask
+	mask.add(cid.ctype)
+
+	if mask.hasAll(&o.filter) {
+		o.emit(ObserverExit, eid)
+	}
+}
+
+func (o *Observer) componentReplaced(*ComponentID, Component, Component) {
+	// Replacing a component does not change an entity's component mask.
+}
+
+func (o *Observer) sceneCompacted(remap *Remapping) {
+	// Compacting a scene does not change any entity's component mask, but it may
+	// move the entities named by buffered events.
+	for i := range o.events {
+		o.events[i].Entity, _ = remap.Entity(&o.events[i].Entity)
+	}
+}
+
+func (o *Observer) emit(kind ObserverEventKind, eid *EntityID) {
+	if o.buffered {
+		o.events = append(o.events, ObserverEvent{kind, *eid})
+	}
+
+	if kind == ObserverEnter && o.onEnter != nil {
+		o.onEnter(o.scene, *eid)
+	} else if kind == ObserverExit && o.onExit != nil {
+		o.onExit(o.scene, *eid)
+	}
+}
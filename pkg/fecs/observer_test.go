@@ -0,0 +1,158 @@
+package fecs_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func enter(id fecs.EntityID) fecs.ObserverEvent {
+	return fecs.ObserverEvent{Kind: fecs.ObserverEnter, Entity: id}
+}
+
+func exit(id fecs.EntityID) fecs.ObserverEvent {
+	return fecs.ObserverEvent{Kind: fecs.ObserverExit, Entity: id}
+}
+
+func TestObserverEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter []fecs.ComponentType
+		change func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent
+	}{
+		{"attach completes the filter", []fecs.ComponentType{positionType, velocityType}, func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent {
+			s.AttachComponent(id, newPosition(0, 0))
+			s.AttachComponent(id, newVelocity(0, 0))
+			return []fecs.ObserverEvent{enter(*id)}
+		}},
+		{"remove breaks the filter", []fecs.ComponentType{positionType, velocityType}, func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent {
+			s.AttachComponent(id, newPosition(0, 0))
+			s.AttachComponent(id, newVelocity(0, 0))
+			removeComponent(s, *id, healthType)
+			removeComponent(s, *id, velocityType)
+			removeComponent(s, *id, positionType)
+			return []fecs.ObserverEvent{enter(*id), exit(*id)}
+		}},
+		{"destroy while matching", []fecs.ComponentType{positionType}, func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent {
+			s.AttachComponent(id, newPosition(0, 0))
+			s.DestroyEntity(id)
+			return []fecs.ObserverEvent{enter(*id), exit(*id)}
+		}},
+		{"destroy while not matching", []fecs.ComponentType{positionType, velocityType}, func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent {
+			s.AttachComponent(id, newPosition(0, 0))
+			s.DestroyEntity(id)
+			return nil
+		}},
+		{"replace keeps matching", []fecs.ComponentType{positionType}, func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent {
+			cid := s.AttachComponent(id, newPosition(0, 0))
+			s.ReplaceComponent(&cid, newPosition(1, 1))
+			return []fecs.ObserverEvent{enter(*id)}
+		}},
+		{"empty filter", nil, func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent {
+			s.AttachComponent(id, newPosition(0, 0))
+			removeComponent(s, *id, positionType)
+
+			other := s.NewEntity()
+			s.DestroyEntity(id)
+			return []fecs.ObserverEvent{enter(other), exit(*id)}
+		}},
+		{"destroy batch", []fecs.ComponentType{positionType}, func(s fecs.Scene, id *fecs.EntityID) []fecs.ObserverEvent {
+			ids := s.SpawnBatch(2, newPosition(0, 0))
+			s.DestroyBatch(ids)
+			return []fecs.ObserverEvent{enter(ids[0]), enter(ids[1]), exit(ids[0]), exit(ids[1])}
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene := fecs.NewScene()
+			id := scene.NewEntity()
+			scene.AttachComponent(&id, newHealth(1))
+
+			observer := fecs.NewObserver(scene, test.filter...)
+			observer.SetBuffered(true)
+
+			var called []fecs.ObserverEvent
+			observer.OnEnter(func(_ fecs.Scene, id fecs.EntityID) { called = append(called, enter(id)) })
+			observer.OnExit(func(_ fecs.Scene, id fecs.EntityID) { called = append(called, exit(id)) })
+
+			want := test.change(scene, &id)
+
+			if got := observer.Events(); !slices.Equal(got, want) {
+				t.Errorf("Events() = %v, want %v", got, want)
+			}
+
+			if !slices.Equal(called, want) {
+				t.Errorf("callbacks saw %v, want %v", called, want)
+			}
+
+			if got := observer.Events(); got != nil {
+				t.Errorf("second Events() = %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestObserverBuffering(t *testing.T) {
+	scene := fecs.NewScene()
+	observer := fecs.NewObserver(scene, positionType)
+
+	id := scene.NewEntity()
+	scene.AttachComponent(&id, newPosition(0, 0))
+
+	if got := observer.Events(); got != nil {
+		t.Errorf("unbuffered observer recorded %v", got)
+	}
+
+	if !observer.Matches(&id) {
+		t.Errorf("Matches() = false for a matching entity")
+	}
+
+	observer.SetBuffered(true)
+	removeComponent(scene, id, positionType)
+
+	if observer.Matches(&id) {
+		t.Errorf("Matches() = true for an entity that no longer matches")
+	}
+
+	observer.SetBuffered(false)
+	observer.SetBuffered(true)
+
+	if got := observer.Events(); got != nil {
+		t.Errorf("disabling buffering kept %v", got)
+	}
+
+	observer.Close()
+	scene.AttachComponent(&id, newPosition(0, 0))
+
+	if got := observer.Events(); got != nil {
+		t.Errorf("closed observer recorded %v", got)
+	}
+}
+
+func TestObserverFollowsCompaction(t *testing.T) {
+	scene := fecs.NewScene()
+	observer := fecs.NewObserver(scene, positionType)
+	observer.SetBuffered(true)
+
+	ids := scene.SpawnBatch(3, newHealth(1))
+	scene.DestroyEntity(&ids[0])
+
+	scene.AttachComponent(&ids[2], newPosition(0, 0))
+
+	remap := scene.Compact()
+	moved, ok := remap.Entity(&ids[2])
+	if !ok {
+		t.Fatalf("expected compaction to move the observed entity")
+	}
+
+	want := []fecs.ObserverEvent{enter(moved)}
+	if got := observer.Events(); !slices.Equal(got, want) {
+		t.Errorf("Events() = %v, want %v", got, want)
+	}
+
+	if !observer.Matches(&moved) {
+		t.Errorf("Matches() = false for the moved entity")
+	}
+}
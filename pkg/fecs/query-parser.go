@@ -0,0 +1,376 @@
+package fecs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// QuerySyntaxError is returned by ParseQuery when a query expression is
+// malformed or refers to an unknown ComponentType.
+type QuerySyntaxError struct {
+	// Query holds the query expression that failed to parse.
+	Query string
+
+	// Offset holds the byte offset into Query at which the error was found.
+	Offset int
+
+	// Message describes the error.
+	Message string
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return fmt.Sprintf("invalid query %q at offset %d: %s", e.Query, e.Offset, e.Message)
+}
+
+// Pointer returns the query expression with a second line containing a caret
+// pointing at the location of the error, suitable for console output.
+func (e *QuerySyntaxError) Pointer() string {
+	return e.Query + "\n" + strings.Repeat(" ", e.Offset) + "^"
+}
+
+type queryTokenKind uint8
+
+const (
+	queryTokenEnd queryTokenKind = iota
+	queryTokenName
+	queryTokenAnd
+	queryTokenOr
+	queryTokenNot
+	queryTokenOpen
+	queryTokenClose
+)
+
+func (k queryTokenKind) String() string {
+	switch k {
+	case queryTokenEnd:
+		return "end of query"
+	case queryTokenName:
+		return "component type name"
+	case queryTokenAnd:
+		return "'&'"
+	case queryTokenOr:
+		return "'|'"
+	case queryTokenNot:
+		return "'!'"
+	case queryTokenOpen:
+		return "'('"
+	case queryTokenClose:
+		return "')'"
+	default:
+		panic("illegal state")
+	}
+}
+
+type queryToken struct {
+	kind   queryTokenKind
+	offset int
+	text   string
+}
+
+func (t *queryToken) describe() string {
+	if t.kind == queryTokenName {
+		return strconv.Quote(t.text)
+	}
+
+	return t.kind.String()
+}
+
+type queryNodeKind uint8
+
+const (
+	queryNodeType queryNodeKind = iota
+	queryNodeNot
+	queryNodeAnd
+	queryNodeOr
+)
+
+type queryNode struct {
+	kind  queryNodeKind
+	ctype ComponentType
+	left  *queryNode
+	right *queryNode
+}
+
+// queryParser is a recursive descent parser for the query language:
+//
+//	expr    = and { "|" and }
+//	and     = unary { "&" unary }
+//	unary   = "!" unary | primary
+//	primary = name | "(" expr ")"
+type queryParser struct {
+	source string
+	pos    int
+	next   queryToken
+}
+
+func (p *queryParser) parse() (*queryNode, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.next.kind == queryTokenEnd {
+		return nil, p.fail(p.next.offset, "empty query")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.next.kind != queryTokenEnd {
+		if p.next.kind == queryTokenClose {
+			return nil, p.fail(p.next.offset, "unmatched ')'")
+		}
+
+		return nil, p.fail(p.next.offset, "unexpected "+p.next.describe()+", expected '&', '|' or end of query")
+	}
+
+	return node, nil
+}
+
+func (p *queryParser) parseOr() (*queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.next.kind == queryTokenOr {
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &queryNode{kind: queryNodeOr, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.next.kind == queryTokenAnd {
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &queryNode{kind: queryNodeAnd, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (*queryNode, error) {
+	if p.next.kind != queryTokenNot {
+		return p.parsePrimary()
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	inner, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &queryNode{kind: queryNodeNot, left: inner}, nil
+}
+
+func (p *queryParser) parsePrimary() (*queryNode, error) {
+	tok := p.next
+
+	switch tok.kind {
+	case queryTokenName:
+		ct, err := p.resolve(&tok)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+
+		return &queryNode{kind: queryNodeType, ctype: ct}, nil
+
+	case queryTokenOpen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.next.kind == queryTokenClose {
+			return nil, p.fail(p.next.offset, "empty parentheses")
+		}
+
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.next.kind != queryTokenClose {
+			return nil, p.fail(p.next.offset, "unexpected "+p.next.describe()+", expected ')' to close '(' at offset "+strconv.Itoa(tok.offset))
+		}
+
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+
+		return inner, nil
+
+	default:
+		return nil, p.fail(tok.offset, "unexpected "+tok.describe()+", expected a component type name, '!' or '('")
+	}
+}
+
+// resolve looks up the ComponentType named by the given name token.
+//
+// ComponentTypes registered without a name may be referred to by their String
+// form, e.g. "ct-1f".
+func (p *queryParser) resolve(tok *queryToken) (ComponentType, error) {
+	if ct, ok := ComponentTypeByName(tok.text); ok {
+		return ct, nil
+	}
+
+	if hex, ok := strings.CutPrefix(tok.text, "ct-"); ok {
+		componentTypeLock.RLock()
+		registered := currentComponentTypeId
+		componentTypeLock.RUnlock()
+
+		if v, err := strconv.ParseUint(hex, 16, 8); err == nil && v > 0 && v <= uint64(registered) {
+			return ComponentType(v), nil
+		}
+	}
+
+	msg := "unknown component type " + strconv.Quote(tok.text)
+	if guess, ok := closestComponentTypeName(tok.text); ok {
+		msg += " (did you mean " + strconv.Quote(guess) + "?)"
+	}
+
+	return 0, p.fail(tok.offset, msg)
+}
+
+// advance reads the next token from the source into p.next.
+func (p *queryParser) advance() error {
+	for p.pos < len(p.source) && unicode.IsSpace(rune(p.source[p.pos])) {
+		p.pos++
+	}
+
+	start := p.pos
+
+	if p.pos >= len(p.source) {
+		p.next = queryToken{kind: queryTokenEnd, offset: start}
+		return nil
+	}
+
+	kind := queryTokenName
+	switch p.source[p.pos] {
+	case '&':
+		kind = queryTokenAnd
+	case '|':
+		kind = queryTokenOr
+	case '!':
+		kind = queryTokenNot
+	case '(':
+		kind = queryTokenOpen
+	case ')':
+		kind = queryTokenClose
+	}
+
+	if kind != queryTokenName {
+		p.pos++
+
+		// Be forgiving of the doubled operators used by most programming
+		// languages.
+		if (kind == queryTokenAnd || kind == queryTokenOr) && p.pos < len(p.source) && p.source[p.pos] == p.source[start] {
+			p.pos++
+		}
+
+		p.next = queryToken{kind: kind, offset: start, text: p.source[start:p.pos]}
+		return nil
+	}
+
+	for p.pos < len(p.source) && isQueryNameByte(p.source[p.pos]) {
+		p.pos++
+	}
+
+	if p.pos == start {
+		return p.fail(start, "unexpected character "+strconv.QuoteRune(rune(p.source[start])))
+	}
+
+	p.next = queryToken{kind: queryTokenName, offset: start, text: p.source[start:p.pos]}
+	return nil
+}
+
+func (p *queryParser) fail(offset int, message string) error {
+	return &QuerySyntaxError{Query: p.source, Offset: offset, Message: message}
+}
+
+// isQueryNameByte tests whether the given byte may appear in a component type
+// name in a query expression.
+func isQueryNameByte(b byte) bool {
+	switch b {
+	case '&', '|', '!', '(', ')', ' ', '\t', '\n', '\r', '\v', '\f':
+		return false
+	default:
+		return b > 0x20 && b != 0x7f
+	}
+}
+
+// closestComponentTypeName returns the registered component type name that is
+// the fewest edits away from the given name, if any is close enough to be a
+// likely typo.
+func closestComponentTypeName(name string) (string, bool) {
+	best, bestDistance := "", len(name)/3+1
+
+	componentTypeLock.RLock()
+	defer componentTypeLock.RUnlock()
+
+	for candidate := range componentTypesByName {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(candidate)); d < bestDistance || d == bestDistance && best != "" && candidate < best {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	return best, best != ""
+}
+
+// editDistance returns the Levenshtein distance between the given strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,154 @@
+package fecs
+
+import "fmt"
+
+// maxQueryTerms is the largest number of alternatives a query expression may
+// compile down to.
+const maxQueryTerms = 256
+
+// ParseQuery compiles the given query expression into a Query.
+//
+// Query expressions are boolean expressions over the names of ComponentTypes
+// registered with NewNamedComponentType, using the operators '&' (and), '|'
+// (or), and '!' (not), along with parentheses for grouping.  '!' binds tighter
+// than '&', which binds tighter than '|'.
+//
+//	Position & Velocity & !Frozen & (Player | Ally)
+//
+// ComponentTypes registered without a name may be referred to by their String
+// form, e.g. "ct-1f".
+//
+// Returns a *QuerySyntaxError if the given expression is malformed or refers
+// to an unknown ComponentType.
+func ParseQuery(expr string) (*Query, error) {
+	parser := queryParser{source: expr}
+
+	root, err := parser.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	terms, ok := compileQuery(root, false)
+	if !ok {
+		return nil, &QuerySyntaxError{
+			Query:   expr,
+			Message: fmt.Sprintf("query is too complex, it expands to more than %d alternatives", maxQueryTerms),
+		}
+	}
+
+	return &Query{source: expr, terms: terms}, nil
+}
+
+// MustParseQuery compiles the given query expression into a Query.
+//
+// If the given expression is invalid, this function will panic.  See
+// ParseQuery.
+func MustParseQuery(expr string) *Query {
+	q, err := ParseQuery(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+// Query is a compiled query expression that tests the set of ComponentTypes
+// attached to an entity.  See ParseQuery.
+//
+// Queries may be run against a Scene with Scene.Query and Scene.EachQuery.
+type Query struct {
+	source string
+
+	// terms holds the query in disjunctive normal form: an entity matches if it
+	// matches any one of the terms.
+	terms []queryTerm
+}
+
+// matches tests whether the given component mask satisfies this Query.
+func (q *Query) matches(mask *componentMask) bool {
+	for i := range q.terms {
+		if q.terms[i].matches(mask) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the expression this Query was compiled from.
+func (q *Query) String() string {
+	return q.source
+}
+
+// queryTerm matches entities that have all the ComponentTypes in include and
+// none of the ComponentTypes in exclude.
+type queryTerm struct {
+	include componentMask
+	exclude componentMask
+}
+
+func (t *queryTerm) matches(mask *componentMask) bool {
+	return mask.hasAll(&t.include) && !mask.hasAny(&t.exclude)
+}
+
+// compileQuery converts the given query expression tree into disjunctive normal
+// form, negating it if negate is true.
+//
+// Terms that can never match are dropped.  Returns false if the result would
+// have more than maxQueryTerms terms.
+func compileQuery(node *queryNode, negate bool) ([]queryTerm, bool) {
+	switch node.kind {
+	case queryNodeType:
+		term := queryTerm{}
+		if negate {
+			term.exclude.add(node.ctype)
+		} else {
+			term.include.add(node.ctype)
+		}
+
+		return []queryTerm{term}, true
+
+	case queryNodeNot:
+		return compileQuery(node.left, !negate)
+	}
+
+	left, ok := compileQuery(node.left, negate)
+	if !ok {
+		return nil, false
+	}
+
+	right, ok := compileQuery(node.right, negate)
+	if !ok {
+		return nil, false
+	}
+
+	// By De Morgan's laws, a negated and is an or of the negated operands, and a
+	// negated or is an and of the negated operands.
+	if (node.kind == queryNodeOr) != negate {
+		if len(left)+len(right) > maxQueryTerms {
+			return nil, false
+		}
+
+		return append(left, right...), true
+	}
+
+	if len(left)*len(right) > maxQueryTerms {
+		return nil, false
+	}
+
+	out := make([]queryTerm, 0, len(left)*len(right))
+
+	for i := range left {
+		for j := range right {
+			term := left[i]
+			term.include.merge(&right[j].include)
+			term.exclude.merge(&right[j].exclude)
+
+			if !term.include.hasAny(&term.exclude) {
+				out = append(out, term)
+			}
+		}
+	}
+
+	return out, true
+}
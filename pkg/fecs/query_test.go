@@ -0,0 +1,163 @@
+package fecs_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+func TestSceneEachQuery(t *testing.T) {
+	scene, ids := fecstest.NewScene(t, fecstest.Fixture{
+		"a": {&Position{}, &Velocity{}},
+		"b": {&Position{}},
+	})
+
+	seq := scene.EachQuery(fecs.MustParseQuery("Position & !Velocity"))
+	want := []fecs.EntityID{ids["b"]}
+
+	for i := range 2 {
+		if got := slices.Collect(seq); !slices.Equal(got, want) {
+			t.Errorf("range %d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	// Entities added after the sequence was created are seen by later ranges.
+	added := scene.SpawnBatch(40, newPosition(0, 0))
+
+	if got := len(slices.Collect(seq)); got != len(added)+1 {
+		t.Errorf("after spawning, got %d entities, want %d", got, len(added)+1)
+	}
+}
+
+// unnamedType is a ComponentType registered without a name, which queries may
+// only refer to by its String form.
+var unnamedType = fecs.NewComponentType()
+
+func TestParseQueryMatches(t *testing.T) {
+	type has struct{ pos, vel, hp, frozen bool }
+
+	tests := []struct {
+		expr string
+		want func(h has) bool
+	}{
+		{"Position", func(h has) bool { return h.pos }},
+		{"!Position", func(h has) bool { return !h.pos }},
+		{"!!Position", func(h has) bool { return h.pos }},
+		{"Position & Velocity", func(h has) bool { return h.pos && h.vel }},
+		{"Position && Velocity", func(h has) bool { return h.pos && h.vel }},
+		{"Position | Velocity", func(h has) bool { return h.pos || h.vel }},
+		{"Position || Velocity", func(h has) bool { return h.pos || h.vel }},
+		{"Position & !Position", func(h has) bool { return false }},
+		{"Position | !Position", func(h has) bool { return true }},
+		{"Position | Velocity & Health", func(h has) bool { return h.pos || h.vel && h.hp }},
+		{"(Position | Velocity) & Health", func(h has) bool { return (h.pos || h.vel) && h.hp }},
+		{"!(Position & Velocity)", func(h has) bool { return !(h.pos && h.vel) }},
+		{"!(Position | Velocity) & !Frozen", func(h has) bool { return !(h.pos || h.vel) && !h.frozen }},
+		{"!((Position | !Velocity) & (Health | Frozen))", func(h has) bool { return !((h.pos || !h.vel) && (h.hp || h.frozen)) }},
+		{"Position & Velocity & !Frozen & (Health | !Health)", func(h has) bool { return h.pos && h.vel && !h.frozen }},
+		{"\tPosition\n&\nVelocity ", func(h has) bool { return h.pos && h.vel }},
+		{positionType.String() + " & !" + velocityType.String(), func(h has) bool { return h.pos && !h.vel }},
+	}
+
+	// One entity for every combination of the four component types.
+	scene := fecs.NewScene()
+	states := make(map[fecs.EntityID]has, 16)
+
+	for bits := range 16 {
+		h := has{bits&1 != 0, bits&2 != 0, bits&4 != 0, bits&8 != 0}
+		id := scene.NewEntity()
+
+		if h.pos {
+			scene.AttachComponent(&id, newPosition(0, 0))
+		}
+		if h.vel {
+			scene.AttachComponent(&id, newVelocity(0, 0))
+		}
+		if h.hp {
+			scene.AttachComponent(&id, newHealth(1))
+		}
+		if h.frozen {
+			scene.AttachComponent(&id, newFrozen)
+		}
+
+		states[id] = h
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			var want []fecs.EntityID
+			for id, h := range states {
+				if test.want(h) {
+					want = append(want, id)
+				}
+			}
+
+			fecstest.AssertQueryMatches(t, scene, test.expr, want...)
+		})
+	}
+}
+
+func TestParseQueryUnnamedType(t *testing.T) {
+	scene := fecs.NewScene()
+	id := scene.NewEntity()
+	scene.AttachComponent(&id, func() fecs.Component { return unnamed{} })
+	scene.NewEntity()
+
+	fecstest.AssertQueryMatches(t, scene, unnamedType.String(), id)
+}
+
+type unnamed struct{}
+
+func (unnamed) Type() fecs.ComponentType {
+	return unnamedType
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		offset  int
+		message string
+	}{
+		{"", 0, "empty query"},
+		{"   ", 3, "empty query"},
+		{"Position &", 10, "expected a component type name"},
+		{"& Position", 0, "unexpected"},
+		{"Position Velocity", 9, "unexpected"},
+		{"(Position", 9, "expected ')' to close '(' at offset 0"},
+		{"Position)", 8, "unmatched ')'"},
+		{"()", 1, "empty parentheses"},
+		{"Position & Nope", 11, `unknown component type "Nope"`},
+		{"Positoin", 0, `did you mean "Position"?`},
+		{"position", 0, `did you mean "Position"?`},
+		{"Velocity | Xyzzy", 11, `unknown component type "Xyzzy"`},
+		{"ct-0", 0, "unknown component type"},
+		{"ct-ff", 0, "unknown component type"},
+		{"Position # Velocity", 9, "unexpected"},
+		{strings.Repeat("(Position | Velocity) & ", 8) + "(Health | Frozen)", 0, "too complex"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := fecs.ParseQuery(test.expr)
+
+			var syntax *fecs.QuerySyntaxError
+			if !errors.As(err, &syntax) {
+				t.Fatalf("ParseQuery() = %v, want a *QuerySyntaxError", err)
+			}
+
+			if syntax.Offset != test.offset || !strings.Contains(syntax.Message, test.message) {
+				t.Errorf("ParseQuery() = %v, want offset %d and a message containing %q", err, test.offset, test.message)
+			}
+
+			if want := test.expr + "\n" + strings.Repeat(" ", test.offset) + "^"; syntax.Pointer() != want {
+				t.Errorf("Pointer() = %q, want %q", syntax.Pointer(), want)
+			}
+
+			mustPanic(t, "MustParseQuery()", func() { fecs.MustParseQuery(test.expr) })
+		})
+	}
+}
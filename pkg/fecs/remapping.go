@@ -0,0 +1,81 @@
+package fecs
+
+import "iter"
+
+func newRemapping() *Remapping {
+	return &Remapping{
+		entities:   make(map[EntityID]EntityID, 32),
+		components: make(map[ComponentID]ComponentID, 32),
+	}
+}
+
+// Remapping records the EntityIDs and ComponentIDs that were changed by a call
+// to Scene.Compact.
+//
+// Only the IDs of entities and Components that were moved are recorded; IDs of
+// entities and Components that were not moved remain valid.
+type Remapping struct {
+	entities   map[EntityID]EntityID
+	components map[ComponentID]ComponentID
+}
+
+// Entity returns the new EntityID of the entity that was identified by the
+// given EntityID before compaction, and true.
+//
+// If the target entity was not moved, or did not exist, this method returns the
+// given EntityID and false.
+func (r *Remapping) Entity(id *EntityID) (EntityID, bool) {
+	if out, ok := r.entities[*id]; ok {
+		return out, true
+	}
+
+	return *id, false
+}
+
+// Component returns the new ComponentID of the Component that was identified
+// by the given ComponentID before compaction, and true.
+//
+// If the target Component was not moved, or did not exist, this method returns
+// the given ComponentID and false.
+func (r *Remapping) Component(id *ComponentID) (ComponentID, bool) {
+	if out, ok := r.components[*id]; ok {
+		return out, true
+	}
+
+	return *id, false
+}
+
+// MovedEntities returns the number of entities that were given new EntityIDs.
+func (r *Remapping) MovedEntities() int {
+	return len(r.entities)
+}
+
+// MovedComponents returns the number of Components that were given new
+// ComponentIDs.
+func (r *Remapping) MovedComponents() int {
+	return len(r.components)
+}
+
+// Entities returns an iter.Seq2 over the old and new EntityIDs of every entity
+// that was moved, in no particular order.
+func (r *Remapping) Entities() iter.Seq2[EntityID, EntityID] {
+	return func(yield func(EntityID, EntityID) bool) {
+		for from, to := range r.entities {
+			if !yield(from, to) {
+				return
+			}
+		}
+	}
+}
+
+// Components returns an iter.Seq2 over the old and new ComponentIDs of every
+// Component that was moved, in no particular order.
+func (r *Remapping) Components() iter.Seq2[ComponentID, ComponentID] {
+	return func(yield func(ComponentID, ComponentID) bool) {
+		for from, to := range r.components {
+			if !yield(from, to) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package fecs_test
+
+import (
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+func TestSceneCompact(t *testing.T) {
+	tests := []struct {
+		name       string
+		destroy    []string
+		remove     map[string]fecs.ComponentType
+		movedEnts  int
+		movedComps int
+	}{
+		{"nothing to compact", nil, nil, 0, 0},
+		{"trailing hole", []string{"e"}, nil, 0, 0},
+		{"leading hole", []string{"a"}, nil, 4, 4},
+		{"scattered holes", []string{"a", "c"}, nil, 3, 3},
+		{"everything destroyed", []string{"a", "b", "c", "d", "e"}, nil, 0, 0},
+		{"removed component", nil, map[string]fecs.ComponentType{"a": positionType}, 0, 4},
+		{"removed and destroyed", []string{"b"}, map[string]fecs.ComponentType{"c": healthType}, 3, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fixture := fecstest.Fixture{}
+			for i, name := range []string{"a", "b", "c", "d", "e"} {
+				fixture[name] = []fecs.Component{&Position{float64(i), 0}}
+			}
+
+			fixture["c"] = append(fixture["c"], &Health{3})
+
+			scene, ids := fecstest.NewScene(t, fixture)
+
+			for _, name := range test.destroy {
+				id := ids[name]
+				scene.DestroyEntity(&id)
+				delete(ids, name)
+			}
+
+			for name, ct := range test.remove {
+				removeComponent(scene, ids[name], ct)
+			}
+
+			// Record the surviving contents by entity name.
+			before := make(map[string]map[fecs.ComponentID]fecs.Component, len(ids))
+			for name, id := range ids {
+				before[name] = make(map[fecs.ComponentID]fecs.Component)
+
+				for _, cid := range scene.Components(&id) {
+					comp, _ := scene.GetComponent(&cid)
+					before[name][cid] = comp
+				}
+			}
+
+			remap := scene.Compact()
+
+			if got := remap.MovedEntities(); got != test.movedEnts {
+				t.Errorf("MovedEntities() = %d, want %d", got, test.movedEnts)
+			}
+
+			if got := remap.MovedComponents(); got != test.movedComps {
+				t.Errorf("MovedComponents() = %d, want %d", got, test.movedComps)
+			}
+
+			var old []fecs.EntityID
+
+			for name, id := range ids {
+				now, moved := remap.Entity(&id)
+
+				if moved {
+					old = append(old, id)
+
+					if scene.ContainsEntity(&id) {
+						t.Errorf("old ID %v of moved entity %q is still valid", id, name)
+					}
+				} else if now != id {
+					t.Errorf("Entity(%v) = %v, false, want the given ID", id, now)
+				}
+
+				if !scene.ContainsEntity(&now) {
+					t.Fatalf("entity %q is missing after compaction", name)
+				}
+
+				cids := scene.Components(&now)
+				if len(cids) != len(before[name]) {
+					t.Errorf("entity %q has %d components, want %d", name, len(cids), len(before[name]))
+				}
+
+				for cid, comp := range before[name] {
+					newID, _ := remap.Component(&cid)
+
+					if got, ok := scene.GetComponent(&newID); !ok || got != comp {
+						t.Errorf("component %v of entity %q is not at %v", cid, name, newID)
+					}
+				}
+			}
+
+			count := 0
+			for range remap.Entities() {
+				count++
+			}
+
+			if count != remap.MovedEntities() {
+				t.Errorf("Entities() yielded %d pairs, want %d", count, remap.MovedEntities())
+			}
+
+			fecstest.AssertEntityCount(t, scene, len(ids))
+
+			if v := scene.Validate(); len(v) > 0 {
+				t.Errorf("Validate() = %v", v)
+			}
+
+			// Old IDs of moved entities are never handed out again.
+			for range 10 {
+				id := scene.NewEntity()
+
+				for _, o := range old {
+					if id == o {
+						t.Errorf("NewEntity() reused the old ID %v of a moved entity", o)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRemappingUnknownIDs(t *testing.T) {
+	scene := fecs.NewScene()
+	id := scene.NewEntity()
+	cid := scene.AttachComponent(&id, newFrozen)
+
+	remap := scene.Compact()
+
+	if got, ok := remap.Entity(&id); ok || got != id {
+		t.Errorf("Entity() of an unmoved entity = %v, %t", got, ok)
+	}
+
+	if got, ok := remap.Component(&cid); ok || got != cid {
+		t.Errorf("Component() of an unmoved component = %v, %t", got, ok)
+	}
+
+	for range remap.Components() {
+		t.Errorf("Components() yielded a pair for a scene with nothing moved")
+	}
+}
@@ -0,0 +1,736 @@
+package fecs
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxReplicationFrameSize is the largest replication frame a ReplicationSink
+// will accept.
+const maxReplicationFrameSize = 64 << 20
+
+// NewReplicationSource creates a new ReplicationSource that tracks the changes
+// made to the given Scene.
+//
+// The first delta written by the returned ReplicationSource will contain every
+// entity and replicated component already present in the Scene, so a fresh
+// ReplicationSource should be created for each receiving end.
+//
+// The given Scene must have been created by NewScene, otherwise this function
+// will panic.
+func NewReplicationSource(s Scene) *ReplicationSource {
+	impl, ok := s.(*scene)
+	if !ok {
+		panic(fmt.Errorf("cannot replicate scene %s, only scenes created by NewScene may be replicated", s.String()))
+	}
+
+	out := &ReplicationSource{
+		scene:      impl,
+		createdSet: make(map[EntityID]bool, 32),
+		attachSet:  make(map[ComponentID]bool, 32),
+		last:       make(map[ComponentID][]byte, 32),
+	}
+
+	for it := impl.entities.entities(nil); it.HasNext(); {
+		eid := it.Next()
+		out.entityCreated(&eid)
+
+		for _, cid := range impl.entities.getEntityComponents(&eid) {
+			if comp, ok := impl.GetComponent(cid); ok {
+				out.componentAttached(&eid, cid, comp)
+			}
+		}
+	}
+
+	impl.addListener(out)
+
+	return out
+}
+
+// ReplicationSource records the structural and value changes made to a Scene
+// and writes them out as per-tick deltas that may be applied to another Scene
+// by a ReplicationSink.
+//
+// Only Components that implement encoding.BinaryMarshaler are replicated.
+// Value changes are detected by comparing each Component's binary form against
+// the form that was last written, so Components may be mutated in place.
+//
+// Components are identified in deltas by their ComponentType number, not their
+// name, so the sending and receiving programs must create their ComponentTypes
+// in the same order.
+type ReplicationSource struct {
+	scene *scene
+	tick  uint64
+
+	created    []EntityID
+	createdSet map[EntityID]bool
+	destroyed  []EntityID
+	attached   []replicatedComponent
+	attachSet  map[ComponentID]bool
+	removed    []replicatedComponent
+
+	// last holds the most recently written binary form of each replicated
+	// component.
+	last map[ComponentID][]byte
+}
+
+// Tick returns the number of deltas this ReplicationSource has written.
+func (r *ReplicationSource) Tick() uint64 {
+	return r.tick
+}
+
+// Flush writes a single delta frame containing every change made to the Scene
+// since the previous call to Flush to the given io.Writer.
+//
+// If encoding a component or writing the frame fails, the tracked changes are
+// discarded and an error is returned.
+func (r *ReplicationSource) Flush(w io.Writer) error {
+	buf, err := r.encode()
+	r.reset()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf)
+	return err
+}
+
+// Close stops this ReplicationSource from tracking further changes to its
+// Scene.
+func (r *ReplicationSource) Close() {
+	r.scene.removeListener(r)
+}
+
+func (r *ReplicationSource) encode() ([]byte, error) {
+	r.tick++
+
+	// Reserve room for the frame length prefix.
+	buf := make([]byte, 4, 256)
+	buf = binary.AppendUvarint(buf, r.tick)
+
+	buf = binary.AppendUvarint(buf, uint64(len(r.destroyed)))
+	for i := range r.destroyed {
+		buf = appendReplicatedEntity(buf, &r.destroyed[i])
+	}
+
+	// The created and attached lists may contain entries that were cancelled out
+	// or repeated since they were recorded, so only the entries still present in
+	// the matching set are written, each only once.
+	buf = binary.AppendUvarint(buf, uint64(len(r.createdSet)))
+	for i := range r.created {
+		if r.createdSet[r.created[i]] {
+			buf = appendReplicatedEntity(buf, &r.created[i])
+			delete(r.createdSet, r.created[i])
+		}
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(r.removed)))
+	for i := range r.removed {
+		buf = appendReplicatedEntity(buf, &r.removed[i].eid)
+		buf = append(buf, byte(r.removed[i].cid.ctype))
+	}
+
+	attached := make(map[ComponentID]bool, len(r.attachSet))
+
+	buf = binary.AppendUvarint(buf, uint64(len(r.attachSet)))
+	for i := range r.attached {
+		ref := &r.attached[i]
+
+		if !r.attachSet[ref.cid] || attached[ref.cid] {
+			continue
+		}
+
+		data, err := r.marshal(&ref.cid)
+		if err != nil {
+			return nil, err
+		}
+
+		buf = appendReplicatedComponent(buf, &ref.eid, ref.cid.ctype, data)
+		r.last[ref.cid] = data
+		attached[ref.cid] = true
+	}
+
+	var changed []byte
+	var changes uint64
+
+	for it := r.scene.entities.entities(nil); it.HasNext(); {
+		eid := it.Next()
+
+		for _, cid := range r.scene.entities.getEntityComponents(&eid) {
+			prev, ok := r.last[*cid]
+			if !ok || attached[*cid] {
+				continue
+			}
+
+			data, err := r.marshal(cid)
+			if err != nil {
+				return nil, err
+			}
+
+			if string(data) != string(prev) {
+				changed = appendReplicatedComponent(changed, &eid, cid.ctype, data)
+				r.last[*cid] = data
+				changes++
+			}
+		}
+	}
+
+	buf = binary.AppendUvarint(buf, changes)
+	buf = append(buf, changed...)
+
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-4))
+
+	return buf, nil
+}
+
+func (r *ReplicationSource) marshal(cid *ComponentID) ([]byte, error) {
+	comp, ok := r.scene.GetComponent(cid)
+	if !ok {
+		panic("illegal state")
+	}
+
+	data, err := comp.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal component %s: %w", cid.String(), err)
+	}
+
+	return data, nil
+}
+
+func (r *ReplicationSource) reset() {
+	r.created = r.created[:0]
+	r.destroyed = r.destroyed[:0]
+	r.attached = r.attached[:0]
+	r.removed = r.removed[:0]
+	clear(r.createdSet)
+	clear(r.attachSet)
+}
+
+func (r *ReplicationSource) entityCreated(eid *EntityID) {
+	r.created = append(r.created, *eid)
+	r.createdSet[*eid] = true
+}
+
+func (r *ReplicationSource) entityDestroyed(eid *EntityID, comps []componentRecord) {
+	for i := range comps {
+		delete(r.attachSet, comps[i].id)
+		delete(r.last, comps[i].id)
+	}
+
+	// Pending removals are implied by the entity's destruction.
+	for i := 0; i < len(r.removed); i++ {
+		if r.removed[i].eid.Equals(eid) {
+			r.removed = append(r.removed[:i], r.removed[i+1:]...)
+			i--
+		}
+	}
+
+	if r.createdSet[*eid] {
+		delete(r.createdSet, *eid)
+	} else {
+		r.destroyed = append(r.destroyed, *eid)
+	}
+}
+
+func (r *ReplicationSource) componentAttached(eid *EntityID, cid *ComponentID, comp Component) {
+	if _, ok := comp.(encoding.BinaryMarshaler); !ok {
+		return
+	}
+
+	r.attached = append(r.attached, replicatedComponent{*eid, *cid})
+	r.attachSet[*cid] = true
+}
+
+func (r *ReplicationSource) componentRemoved(eid *EntityID, cid *ComponentID, _ Component) {
+	if r.attachSet[*cid] {
+		delete(r.attachSet, *cid)
+		return
+	}
+
+	if _, ok := r.last[*cid]; ok {
+		delete(r.last, *cid)
+		r.removed = append(r.removed, replicatedComponent{*eid, *cid})
+	}
+}
+
+func (r *ReplicationSource) componentReplaced(*ComponentID, Component, Component) {
+	// Replaced values are picked up by comparing binary forms on flush.
+}
+
+func (r *ReplicationSource) sceneCompacted(remap *Remapping) {
+	// Moved components keep their entity, so their pending and previously
+	// written state simply follows them to their new IDs.
+	for from, to := range remap.components {
+		if data, ok := r.last[from]; ok {
+			delete(r.last, from)
+			r.last[to] = data
+		}
+
+		if r.attachSet[from] {
+			delete(r.attachSet, from)
+			r.attachSet[to] = true
+		}
+	}
+
+	for i := range r.attached {
+		r.attached[i].eid, _ = remap.Entity(&r.attached[i].eid)
+		r.attached[i].cid, _ = remap.Component(&r.attached[i].cid)
+	}
+
+	for from, to := range remap.entities {
+		// Entities that have not yet been sent can simply be sent under their new
+		// ID.
+		if r.createdSet[from] {
+			delete(r.createdSet, from)
+			r.createdSet[to] = true
+
+			for i := range r.created {
+				if r.created[i] == from {
+					r.created[i] = to
+				}
+			}
+
+			continue
+		}
+
+		// Entities the receiving end already knows about are replaced with a copy
+		// under their new ID.
+		for i := 0; i < len(r.removed); i++ {
+			if r.removed[i].eid == from {
+				r.removed = append(r.removed[:i], r.removed[i+1:]...)
+				i--
+			}
+		}
+
+		r.destroyed = append(r.destroyed, from)
+		r.entityCreated(&to)
+
+		for _, cid := range r.scene.entities.getEntityComponents(&to) {
+			if comp, ok := r.scene.GetComponent(cid); ok {
+				delete(r.last, *cid)
+				r.componentAttached(&to, cid, comp)
+			}
+		}
+	}
+}
+
+// NewReplicationSink creates a new ReplicationSink that applies received deltas
+// to the given Scene.
+//
+// The given Scene must have been created by NewScene, otherwise this function
+// will panic.
+func NewReplicationSink(s Scene) *ReplicationSink {
+	impl, ok := s.(*scene)
+	if !ok {
+		panic(fmt.Errorf("cannot replicate into scene %s, only scenes created by NewScene may be replicated into", s.String()))
+	}
+
+	return &ReplicationSink{
+		scene: impl,
+		remap: make(map[EntityID]EntityID, 32),
+	}
+}
+
+// ReplicationSink applies deltas written by a ReplicationSource to a Scene.
+//
+// Entities created by a ReplicationSink are given new, local EntityIDs.  The
+// local EntityID for a given source EntityID may be looked up with LocalID.
+//
+// Components are reconstructed using the ComponentDecoder registered for their
+// ComponentType with RegisterComponentDecoder.  ComponentTypes are matched up by
+// number, so the sending and receiving programs must create their
+// ComponentTypes in the same order; see ReplicationSource.
+//
+// Each delta is checked in full before it is applied, so a delta that is
+// rejected leaves the Scene unchanged.  ReplicationSources do not resend
+// deltas however, so after rejecting a delta the Scene no longer mirrors the
+// source.
+type ReplicationSink struct {
+	scene *scene
+	tick  uint64
+	remap map[EntityID]EntityID
+}
+
+// Tick returns the tick number of the most recently applied delta.
+func (r *ReplicationSink) Tick() uint64 {
+	return r.tick
+}
+
+// LocalID returns the EntityID of the local entity mirroring the source entity
+// identified by the given EntityID.
+func (r *ReplicationSink) LocalID(remote *EntityID) (EntityID, bool) {
+	out, ok := r.remap[replicatedEntityKey(remote.index, remote.version)]
+	return out, ok
+}
+
+// Receive reads a single delta frame from the given io.Reader and applies it
+// to the Scene.
+//
+// If the reader is at the end of its stream, io.EOF is returned.  If the frame
+// is malformed, references an unknown entity or a local entity that has since
+// been destroyed, or contains a Component that cannot be decoded, an error is
+// returned and the Scene is left unchanged.
+func (r *ReplicationSink) Receive(rd io.Reader) error {
+	var head [4]byte
+
+	if _, err := io.ReadFull(rd, head[:]); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(head[:])
+	if size > maxReplicationFrameSize {
+		return fmt.Errorf("replication frame size %d exceeds the maximum of %d", size, maxReplicationFrameSize)
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(rd, frame); err != nil {
+		return fmt.Errorf("failed to read replication frame: %w", err)
+	}
+
+	delta, err := readReplicationDelta(&replicationReader{buf: frame})
+	if err != nil {
+		return err
+	}
+
+	// The whole delta is checked against the Scene before any of it is applied,
+	// so that a bad frame cannot leave the Scene half updated.
+	if err = r.check(delta); err != nil {
+		return err
+	}
+
+	return r.apply(delta)
+}
+
+// check tests that every change in the given delta may be applied to the
+// Scene, by replaying the delta against the component masks of the entities
+// it touches.
+func (r *ReplicationSink) check(delta *replicationDelta) error {
+	// Masks of the touched entities, keyed by source entity.  A nil mask marks an
+	// entity destroyed by the delta.
+	masks := make(map[EntityID]*componentMask, len(delta.created)+len(delta.destroyed))
+
+	mask := func(key EntityID) (*componentMask, error) {
+		if m, ok := masks[key]; ok {
+			if m == nil {
+				return nil, fmt.Errorf("received a delta for source entity %s after its destruction", key.String())
+			}
+
+			return m, nil
+		}
+
+		eid, err := r.local(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if !r.scene.ContainsEntity(&eid) {
+			return nil, fmt.Errorf("local mirror %s of source entity %s: %w", eid.String(), key.String(), ErrEntityNotFound)
+		}
+
+		m := r.scene.entities.pool[eid.index].mask
+		masks[key] = &m
+
+		return &m, nil
+	}
+
+	for _, key := range delta.destroyed {
+		if _, err := mask(key); err != nil {
+			return err
+		}
+
+		masks[key] = nil
+	}
+
+	for _, key := range delta.created {
+		m, touched := masks[key]
+		_, known := r.remap[key]
+
+		if touched && m != nil || !touched && known {
+			return fmt.Errorf("received a second creation of source entity %s", key.String())
+		}
+
+		masks[key] = new(componentMask)
+	}
+
+	for i := range delta.removed {
+		m, err := mask(delta.removed[i].key)
+		if err != nil {
+			return err
+		}
+
+		m.remove(delta.removed[i].ctype)
+	}
+
+	for i := range delta.attached {
+		m, err := mask(delta.attached[i].key)
+		if err != nil {
+			return err
+		}
+
+		m.add(delta.attached[i].ctype)
+	}
+
+	for i := range delta.changed {
+		m, err := mask(delta.changed[i].key)
+		if err != nil {
+			return err
+		}
+
+		if !m.has(delta.changed[i].ctype) {
+			return fmt.Errorf("received a change for component type %s which is not attached to source entity %s", delta.changed[i].ctype.String(), delta.changed[i].key.String())
+		}
+	}
+
+	return nil
+}
+
+// apply applies the given delta, which must have passed check, to the Scene.
+func (r *ReplicationSink) apply(delta *replicationDelta) error {
+	for _, key := range delta.destroyed {
+		eid, err := r.local(key)
+		if err != nil {
+			return err
+		}
+
+		if err = r.scene.TryDestroyEntity(&eid); err != nil {
+			return err
+		}
+
+		delete(r.remap, key)
+	}
+
+	for _, key := range delta.created {
+		r.remap[key] = r.scene.NewEntity()
+	}
+
+	for i := range delta.removed {
+		eid, err := r.local(delta.removed[i].key)
+		if err != nil {
+			return err
+		}
+
+		if cid, ok := r.scene.entities.getEntityComponent(&eid, delta.removed[i].ctype); ok {
+			if err = r.scene.TryRemoveComponent(&eid, cid); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range delta.attached {
+		ref := &delta.attached[i]
+
+		eid, err := r.local(ref.key)
+		if err != nil {
+			return err
+		}
+
+		if cid, ok := r.scene.entities.getEntityComponent(&eid, ref.ctype); ok {
+			if err = r.scene.TryRemoveComponent(&eid, cid); err != nil {
+				return err
+			}
+		}
+
+		if _, err = r.scene.TryAttachComponent(&eid, func() Component { return ref.comp }); err != nil {
+			return err
+		}
+	}
+
+	for i := range delta.changed {
+		ref := &delta.changed[i]
+
+		eid, err := r.local(ref.key)
+		if err != nil {
+			return err
+		}
+
+		cid, ok := r.scene.entities.getEntityComponent(&eid, ref.ctype)
+		if !ok || !r.scene.setComponent(cid, ref.comp) {
+			return fmt.Errorf("received a change for component type %s which is not attached to entity %s", ref.ctype.String(), eid.String())
+		}
+	}
+
+	r.tick = delta.tick
+
+	return nil
+}
+
+func (r *ReplicationSink) local(key EntityID) (EntityID, error) {
+	if eid, ok := r.remap[key]; ok {
+		return eid, nil
+	}
+
+	return EntityID{}, fmt.Errorf("received a delta for unknown source entity %s", key.String())
+}
+
+// replicationDelta is the decoded contents of a single replication frame.
+//
+// Entities are identified by their scene-less source EntityIDs, see
+// replicatedEntityKey.
+type replicationDelta struct {
+	tick      uint64
+	destroyed []EntityID
+	created   []EntityID
+	removed   []replicatedValue
+	attached  []replicatedValue
+	changed   []replicatedValue
+}
+
+// replicatedValue is a single component change read from a replication frame.
+// The comp field is nil for removals.
+type replicatedValue struct {
+	key   EntityID
+	ctype ComponentType
+	comp  Component
+}
+
+// readReplicationDelta reads and decodes the whole of a replication frame.
+func readReplicationDelta(rd *replicationReader) (*replicationDelta, error) {
+	out := &replicationDelta{tick: rd.uvarint()}
+
+	for n := rd.uvarint(); n > 0 && rd.err == nil; n-- {
+		out.destroyed = append(out.destroyed, rd.entity())
+	}
+
+	for n := rd.uvarint(); n > 0 && rd.err == nil; n-- {
+		out.created = append(out.created, rd.entity())
+	}
+
+	for n := rd.uvarint(); n > 0 && rd.err == nil; n-- {
+		out.removed = append(out.removed, replicatedValue{key: rd.entity(), ctype: rd.componentType()})
+	}
+
+	for _, list := range []*[]replicatedValue{&out.attached, &out.changed} {
+		for n := rd.uvarint(); n > 0 && rd.err == nil; n-- {
+			value := replicatedValue{key: rd.entity(), ctype: rd.componentType()}
+			data := rd.bytes()
+
+			if rd.err != nil {
+				break
+			}
+
+			comp, err := decodeComponent(value.ctype, data)
+			if err != nil {
+				return nil, err
+			}
+
+			value.comp = comp
+			*list = append(*list, value)
+		}
+	}
+
+	if rd.err != nil {
+		return nil, rd.err
+	}
+
+	return out, nil
+}
+
+// replicatedComponent pairs a ComponentID with the EntityID of the entity it is
+// attached to.
+type replicatedComponent struct {
+	eid EntityID
+	cid ComponentID
+}
+
+// replicatedEntityKey builds the scene-less EntityID used to identify source
+// entities on the receiving end of a replication stream.
+func replicatedEntityKey(index, version uint32) EntityID {
+	return EntityID{index: index, version: version}
+}
+
+func appendReplicatedEntity(buf []byte, eid *EntityID) []byte {
+	buf = binary.AppendUvarint(buf, uint64(eid.index))
+	return binary.AppendUvarint(buf, uint64(eid.version))
+}
+
+func appendReplicatedComponent(buf []byte, eid *EntityID, ct ComponentType, data []byte) []byte {
+	buf = appendReplicatedEntity(buf, eid)
+	buf = append(buf, byte(ct))
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+var errTruncatedFrame = errors.New("truncated replication frame")
+
+// replicationReader reads values out of a replication frame, recording the
+// first error encountered.
+type replicationReader struct {
+	buf []byte
+	err error
+}
+
+func (r *replicationReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errTruncatedFrame
+		return 0
+	}
+
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *replicationReader) uint32() uint32 {
+	v := r.uvarint()
+
+	if v > uint64(^uint32(0)) && r.err == nil {
+		r.err = fmt.Errorf("replication frame value %d overflows uint32", v)
+	}
+
+	return uint32(v)
+}
+
+func (r *replicationReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+
+	if len(r.buf) == 0 {
+		r.err = errTruncatedFrame
+		return 0
+	}
+
+	v := r.buf[0]
+	r.buf = r.buf[1:]
+	return v
+}
+
+func (r *replicationReader) bytes() []byte {
+	size := r.uvarint()
+
+	if r.err != nil {
+		return nil
+	}
+
+	if uint64(len(r.buf)) < size {
+		r.err = errTruncatedFrame
+		return nil
+	}
+
+	v := r.buf[:size]
+	r.buf = r.buf[size:]
+	return v
+}
+
+func (r *replicationReader) componentType() ComponentType {
+	ct := ComponentType(r.byte())
+
+	if ct == 0 && r.err == nil {
+		r.err = errors.New("replication frame contains component type 0")
+	}
+
+	return ct
+}
+
+func (r *replicationReader) entity() EntityID {
+	index := r.uint32()
+	return replicatedEntityKey(index, r.uint32())
+}
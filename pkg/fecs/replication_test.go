@@ -0,0 +1,379 @@
+package fecs_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+var (
+	scoreType  = fecs.NewNamedComponentType("Score")
+	glitchType = fecs.NewNamedComponentType("Glitch")
+)
+
+func init() {
+	fecs.RegisterComponentDecoder(scoreType, func(data []byte) (fecs.Component, error) {
+		if len(data) != 4 {
+			return nil, errors.New("invalid score")
+		}
+
+		return &Score{int32(binary.BigEndian.Uint32(data))}, nil
+	})
+
+	// A broken decoder, which builds a component of the wrong type.
+	fecs.RegisterComponentDecoder(glitchType, func([]byte) (fecs.Component, error) {
+		return &Score{}, nil
+	})
+}
+
+// Glitch is a replicated Component whose registered decoder is broken.
+type Glitch struct{}
+
+func (*Glitch) Type() fecs.ComponentType {
+	return glitchType
+}
+
+func (*Glitch) MarshalBinary() ([]byte, error) {
+	return nil, nil
+}
+
+// Score is the replicated Component used by the replication tests.
+type Score struct {
+	Points int32
+}
+
+func (*Score) Type() fecs.ComponentType {
+	return scoreType
+}
+
+func (s *Score) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint32(nil, uint32(s.Points)), nil
+}
+
+func newScore(points int32) fecs.ComponentConstructor {
+	return func() fecs.Component { return &Score{points} }
+}
+
+// replicationPair holds a source Scene and the Scene it is replicated into.
+type replicationPair struct {
+	src    fecs.Scene
+	dst    fecs.Scene
+	source *fecs.ReplicationSource
+	sink   *fecs.ReplicationSink
+	ids    map[string]fecs.EntityID
+}
+
+// sync flushes the pending changes of the source into the sink.
+func (p *replicationPair) sync(t *testing.T) {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	if err := p.source.Flush(buf); err != nil {
+		t.Fatalf("Flush() = %s", err)
+	}
+
+	if err := p.sink.Receive(buf); err != nil {
+		t.Fatalf("Receive() = %s", err)
+	}
+
+	if p.sink.Tick() != p.source.Tick() {
+		t.Errorf("sink is at tick %d, source at tick %d", p.sink.Tick(), p.source.Tick())
+	}
+}
+
+// assertMirrored tests that the destination Scene holds exactly one entity per
+// source entity, with the same Score.
+func (p *replicationPair) assertMirrored(t *testing.T) {
+	t.Helper()
+
+	count := 0
+
+	for it := p.src.Entities(); it.HasNext(); count++ {
+		id := it.Next()
+
+		local, ok := p.sink.LocalID(&id)
+		if !ok || !p.dst.ContainsEntity(&local) {
+			t.Errorf("source entity %v is not mirrored", id)
+			continue
+		}
+
+		want, wantOK := p.src.GetComponentByType(&id, scoreType)
+		got, gotOK := p.dst.GetComponentByType(&local, scoreType)
+
+		switch {
+		case wantOK != gotOK:
+			t.Errorf("source entity %v has score %t, mirror has score %t", id, wantOK, gotOK)
+		case wantOK && *want.(*Score) != *got.(*Score):
+			t.Errorf("source entity %v has %+v, mirror has %+v", id, want, got)
+		}
+
+		if _, ok := p.dst.GetComponentByType(&local, positionType); ok {
+			t.Errorf("mirror of %v received a component that does not implement encoding.BinaryMarshaler", id)
+		}
+	}
+
+	fecstest.AssertEntityCount(t, p.dst, count)
+
+	if v := p.dst.Validate(); len(v) > 0 {
+		t.Errorf("destination Validate() = %v", v)
+	}
+}
+
+// removeComponent removes the Component of the given ComponentType from the
+// entity with the given EntityID.
+func removeComponent(s fecs.Scene, id fecs.EntityID, ct fecs.ComponentType) {
+	for _, cid := range s.Components(&id) {
+		if cid.Type() == ct {
+			s.RemoveComponent(&id, &cid)
+		}
+	}
+}
+
+func TestReplication(t *testing.T) {
+	tests := []struct {
+		name  string
+		ticks []func(s fecs.Scene, ids map[string]fecs.EntityID)
+	}{
+		{"existing contents", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {},
+		}},
+		{"create and attach", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := s.NewEntity()
+				s.AttachComponent(&id, newScore(7))
+				s.AttachComponent(&id, newPosition(1, 1))
+				ids["new"] = id
+			},
+		}},
+		{"change in place", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := ids["a"]
+				comp, _ := s.GetComponentByType(&id, scoreType)
+				comp.(*Score).Points = 100
+			},
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {},
+		}},
+		{"remove and reattach", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := ids["a"]
+				removeComponent(s, id, scoreType)
+			},
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := ids["a"]
+				s.AttachComponent(&id, newScore(3))
+			},
+		}},
+		{"attach then remove in one tick", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := ids["c"]
+				s.AttachComponent(&id, newScore(5))
+				removeComponent(s, id, scoreType)
+			},
+		}},
+		{"destroy", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := ids["a"]
+				removeComponent(s, id, scoreType)
+				s.DestroyEntity(&id)
+			},
+		}},
+		{"create then destroy in one tick", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := s.NewEntity()
+				s.AttachComponent(&id, newScore(1))
+				s.DestroyEntity(&id)
+			},
+		}},
+		{"compaction", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := ids["a"]
+				s.DestroyEntity(&id)
+			},
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				remap := s.Compact()
+				if remap.MovedEntities() == 0 {
+					panic("expected compaction to move an entity")
+				}
+
+				id := ids["b"]
+				id, _ = remap.Entity(&id)
+				comp, _ := s.GetComponentByType(&id, scoreType)
+				comp.(*Score).Points = 42
+			},
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {},
+		}},
+		{"compaction of unsent entities", []func(fecs.Scene, map[string]fecs.EntityID){
+			func(s fecs.Scene, ids map[string]fecs.EntityID) {
+				id := ids["a"]
+				s.DestroyEntity(&id)
+
+				id = s.NewEntity()
+				s.AttachComponent(&id, newScore(9))
+				s.Compact()
+			},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := fecs.NewScene()
+			ids := make(map[string]fecs.EntityID)
+
+			for _, name := range []string{"a", "b", "c"} {
+				id := src.NewEntity()
+				src.AttachComponent(&id, newScore(int32(len(ids))))
+				ids[name] = id
+			}
+
+			c := ids["c"]
+			removeComponent(src, c, scoreType)
+			src.AttachComponent(&c, newPosition(0, 0))
+
+			dst := fecs.NewScene()
+			pair := &replicationPair{src, dst, fecs.NewReplicationSource(src), fecs.NewReplicationSink(dst), ids}
+
+			for _, tick := range test.ticks {
+				tick(src, ids)
+				pair.sync(t)
+				pair.assertMirrored(t)
+			}
+
+			// An idle tick leaves the destination unchanged.
+			pair.sync(t)
+			pair.assertMirrored(t)
+		})
+	}
+}
+
+func TestReplicationSourceClose(t *testing.T) {
+	src := fecs.NewScene()
+	source := fecs.NewReplicationSource(src)
+	source.Close()
+
+	id := src.NewEntity()
+	src.AttachComponent(&id, newScore(1))
+
+	dst := fecs.NewScene()
+	buf := new(bytes.Buffer)
+
+	mustNoErr(t, source.Flush(buf))
+	mustNoErr(t, fecs.NewReplicationSink(dst).Receive(buf))
+
+	fecstest.AssertEntityCount(t, dst, 0)
+}
+
+func TestReplicationSinkErrors(t *testing.T) {
+	src := fecs.NewScene()
+	source := fecs.NewReplicationSource(src)
+
+	id := src.NewEntity()
+	src.AttachComponent(&id, newScore(1))
+
+	first := new(bytes.Buffer)
+	mustNoErr(t, source.Flush(first))
+
+	comp, _ := src.GetComponentByType(&id, scoreType)
+	comp.(*Score).Points = 2
+
+	second := new(bytes.Buffer)
+	mustNoErr(t, source.Flush(second))
+
+	tests := []struct {
+		name  string
+		frame []byte
+		want  string
+		is    error
+	}{
+		{"end of stream", nil, "", io.EOF},
+		{"truncated frame", first.Bytes()[:first.Len()-1], "", io.ErrUnexpectedEOF},
+		{"truncated contents", append(binary.BigEndian.AppendUint32(nil, 2), 1, 1), "truncated", nil},
+		{"oversized frame", binary.BigEndian.AppendUint32(nil, ^uint32(0)), "exceeds the maximum", nil},
+		{"unknown entity", second.Bytes(), "unknown source entity", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink := fecs.NewReplicationSink(fecs.NewScene())
+			err := sink.Receive(bytes.NewReader(test.frame))
+
+			switch {
+			case err == nil:
+				t.Fatalf("Receive() succeeded, want an error")
+			case test.is != nil && !errors.Is(err, test.is):
+				t.Errorf("Receive() = %v, want %v", err, test.is)
+			case test.want != "" && !strings.Contains(err.Error(), test.want):
+				t.Errorf("Receive() = %v, want an error containing %q", err, test.want)
+			}
+
+			if sink.Tick() != 0 {
+				t.Errorf("sink advanced to tick %d after a failed frame", sink.Tick())
+			}
+		})
+	}
+}
+
+func TestReplicationSinkRejectsWholeFrame(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(src, dst fecs.Scene, sink *fecs.ReplicationSink, id fecs.EntityID)
+		is     error
+	}{
+		{"mirror destroyed locally", func(src, dst fecs.Scene, sink *fecs.ReplicationSink, id fecs.EntityID) {
+			local, _ := sink.LocalID(&id)
+			dst.DestroyEntity(&local)
+
+			comp, _ := src.GetComponentByType(&id, scoreType)
+			comp.(*Score).Points = 2
+		}, fecs.ErrEntityNotFound},
+		{"destroy of a mirror destroyed locally", func(src, dst fecs.Scene, sink *fecs.ReplicationSink, id fecs.EntityID) {
+			local, _ := sink.LocalID(&id)
+			dst.DestroyEntity(&local)
+			src.DestroyEntity(&id)
+		}, fecs.ErrEntityNotFound},
+		{"decoder returns the wrong type", func(src, dst fecs.Scene, _ *fecs.ReplicationSink, id fecs.EntityID) {
+			other := src.NewEntity()
+			src.AttachComponent(&other, newScore(4))
+			src.AttachComponent(&id, func() fecs.Component { return &Glitch{} })
+		}, fecs.ErrWrongComponentType},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := fecs.NewScene()
+			source := fecs.NewReplicationSource(src)
+
+			id := src.NewEntity()
+			src.AttachComponent(&id, newScore(1))
+
+			dst := fecs.NewScene()
+			sink := fecs.NewReplicationSink(dst)
+
+			buf := new(bytes.Buffer)
+			mustNoErr(t, source.Flush(buf))
+			mustNoErr(t, sink.Receive(buf))
+
+			test.change(src, dst, sink, id)
+
+			before := snapshotOf(t, dst)
+
+			mustNoErr(t, source.Flush(buf))
+			if err := sink.Receive(buf); !errors.Is(err, test.is) {
+				t.Errorf("Receive() = %v, want %v", err, test.is)
+			}
+
+			if got := snapshotOf(t, dst); got != before {
+				t.Errorf("a rejected frame changed the scene to\n%s\nfrom\n%s", got, before)
+			}
+
+			if sink.Tick() != 1 {
+				t.Errorf("sink advanced to tick %d after a rejected frame", sink.Tick())
+			}
+		})
+	}
+}
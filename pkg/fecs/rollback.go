@@ -0,0 +1,193 @@
+package fecs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRollbackTooOld is returned when an input arrives for a tick that is older
+// than the history window of a Rollback.
+var ErrRollbackTooOld = errors.New("tick is outside of the rollback history window")
+
+// RollbackSimulation defines a function that advances a Scene by a single tick
+// using the given input.
+//
+// For rollback to produce consistent results, a RollbackSimulation must be
+// deterministic: given the same Scene state and input, it must always make the
+// same changes to the Scene.
+type RollbackSimulation[I interface{}] func(scene Scene, tick uint64, input I)
+
+// NewRollback creates a new Rollback that advances the given Scene using the
+// given simulation function, keeping enough history to rewind up to window
+// ticks into the past.
+//
+// The returned Rollback holds window full copies of the Scene, see Rollback.
+//
+// The given Scene must have been created by NewScene, and the given window
+// must be at least 1, otherwise this function will panic.
+func NewRollback[I interface{}](s Scene, window int, simulate RollbackSimulation[I]) *Rollback[I] {
+	if window < 1 {
+		panic(fmt.Errorf("rollback window must be at least 1, got %d", window))
+	}
+
+	return &Rollback[I]{
+		scene:    requireSceneImpl(s, "roll back"),
+		simulate: simulate,
+		frames:   make([]rollbackFrame, window),
+		inputs:   make(map[uint64]I, window*2),
+	}
+}
+
+// Rollback manages rollback-style resimulation of a Scene.
+//
+// Before each tick is simulated, the full state of the Scene is captured.
+// Every capture is a deep copy of every entity and Component in the Scene, see
+// CaptureState, whether or not it changed since the previous tick, so the cost
+// of each tick grows with the size of the Scene, and a Rollback holds window
+// copies of it in memory.  The slices held by the oldest capture are reused for
+// the next one, but Components held by pointer are still allocated afresh on
+// every capture.  Components kept in a Store are copied by value.
+//
+// When an input arrives for a tick that has already been simulated, the next
+// call to Advance restores the Scene to the state it was in before that tick
+// and re-simulates every tick from there to the present using the stored
+// inputs.
+//
+// Inputs for ticks that have no stored input are passed to the simulation as
+// the zero value of I.  Games that predict missing inputs should store the
+// prediction with SetInput and replace it when the real input arrives.
+type Rollback[I interface{}] struct {
+	scene    *scene
+	simulate RollbackSimulation[I]
+
+	// tick is the next tick to be simulated.
+	tick uint64
+
+	// frames holds the captured state of the scene before each of the most
+	// recently simulated ticks, indexed by tick modulo the window size.
+	frames []rollbackFrame
+
+	inputs map[uint64]I
+
+	// rewindTo is the earliest simulated tick that has received a new input
+	// since it was simulated.
+	rewindTo  uint64
+	hasRewind bool
+
+	resimulated uint64
+}
+
+type rollbackFrame struct {
+	tick  uint64
+	valid bool
+	state SceneState
+}
+
+// Tick returns the number of the next tick to be simulated.
+func (r *Rollback[I]) Tick() uint64 {
+	return r.tick
+}
+
+// Window returns the number of past ticks this Rollback can rewind to.
+func (r *Rollback[I]) Window() int {
+	return len(r.frames)
+}
+
+// Resimulated returns the total number of ticks that have been re-simulated due
+// to late inputs.
+func (r *Rollback[I]) Resimulated() uint64 {
+	return r.resimulated
+}
+
+// Input returns the input stored for the given tick.
+func (r *Rollback[I]) Input(tick uint64) (I, bool) {
+	in, ok := r.inputs[tick]
+	return in, ok
+}
+
+// SetInput stores the input for the given tick.
+//
+// If the given tick has already been simulated, the Scene will be rewound and
+// re-simulated from that tick on the next call to Advance.
+//
+// Returns an error wrapping ErrRollbackTooOld if the given tick has already
+// been simulated and is outside the history window.
+func (r *Rollback[I]) SetInput(tick uint64, input I) error {
+	if tick < r.tick {
+		frame := &r.frames[tick%uint64(len(r.frames))]
+
+		if !frame.valid || frame.tick != tick {
+			return fmt.Errorf("cannot set input for tick %d (current tick %d): %w", tick, r.tick, ErrRollbackTooOld)
+		}
+
+		if !r.hasRewind || tick < r.rewindTo {
+			r.rewindTo = tick
+			r.hasRewind = true
+		}
+	}
+
+	r.inputs[tick] = input
+
+	return nil
+}
+
+// Advance simulates the next tick, first rewinding and re-simulating any past
+// ticks that received new inputs since the previous call.
+//
+// Returns the number of ticks that were simulated, including re-simulated
+// ticks.
+func (r *Rollback[I]) Advance() int {
+	steps := 0
+
+	if r.hasRewind {
+		target := r.tick
+		r.Rewind(r.rewindTo)
+
+		for r.tick < target {
+			r.step()
+			steps++
+		}
+
+		r.resimulated += uint64(steps)
+	}
+
+	r.step()
+
+	return steps + 1
+}
+
+// Rewind restores the Scene to the state it was in before the given tick was
+// simulated, making it the next tick to be simulated.
+//
+// Stored inputs are kept, so subsequent calls to Advance will re-simulate the
+// rewound ticks.
+//
+// If the given tick is not within the history window, this method will panic.
+func (r *Rollback[I]) Rewind(tick uint64) {
+	frame := &r.frames[tick%uint64(len(r.frames))]
+
+	if tick >= r.tick || !frame.valid || frame.tick != tick {
+		panic(fmt.Errorf("cannot rewind to tick %d (current tick %d): %w", tick, r.tick, ErrRollbackTooOld))
+	}
+
+	frame.state.restore(r.scene)
+	r.scene.checkInvariants("Rewind")
+
+	r.tick = tick
+	r.hasRewind = false
+}
+
+func (r *Rollback[I]) step() {
+	frame := &r.frames[r.tick%uint64(len(r.frames))]
+	frame.tick = r.tick
+	frame.valid = true
+	frame.state.capture(r.scene)
+
+	// Drop the input for the tick that just fell out of the history window.
+	if r.tick >= uint64(len(r.frames)) {
+		delete(r.inputs, r.tick-uint64(len(r.frames)))
+	}
+
+	r.simulate(r.scene, r.tick, r.inputs[r.tick])
+	r.tick++
+}
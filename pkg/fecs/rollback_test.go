@@ -0,0 +1,151 @@
+package fecs_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+// simulateRollback moves every entity with a Position by the tick's input, and
+// spawns a new entity every third tick.
+func simulateRollback(scene fecs.Scene, tick uint64, input float64) {
+	for id := range scene.EachEntity(positionType) {
+		pos, _ := scene.GetComponentByType(&id, positionType)
+		pos.(*Position).X += input
+	}
+
+	if tick%3 == 0 {
+		id := scene.NewEntity()
+		scene.AttachComponent(&id, newPosition(float64(tick), 0))
+	}
+}
+
+// positionsOf returns the X coordinate of every Position in the given Scene,
+// in entity order.
+func positionsOf(scene fecs.Scene) []float64 {
+	var out []float64
+
+	for it := scene.Entities(); it.HasNext(); {
+		id := it.Next()
+
+		if pos, ok := scene.GetComponentByType(&id, positionType); ok {
+			out = append(out, pos.(*Position).X)
+		}
+	}
+
+	return out
+}
+
+func TestRollbackLateInputs(t *testing.T) {
+	type late struct {
+		before uint64 // the tick being simulated when the input arrives
+		tick   uint64
+		input  float64
+	}
+
+	tests := []struct {
+		name        string
+		window      int
+		inputs      []late
+		resimulated uint64
+	}{
+		{"on time", 4, []late{{2, 2, 5}, {5, 5, 1}}, 0},
+		{"one tick late", 4, []late{{3, 2, 5}}, 1},
+		{"at the edge of the window", 4, []late{{6, 2, 5}}, 4},
+		{"several late inputs", 4, []late{{4, 3, 1}, {4, 1, 2}, {7, 6, 3}}, 4},
+		{"corrected prediction", 4, []late{{2, 2, 5}, {4, 2, 7}}, 2},
+	}
+
+	const ticks = 10
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The reference scene receives every input on time.
+			reference := fecs.NewScene()
+			ref := fecs.NewRollback(reference, test.window, simulateRollback)
+
+			final := map[uint64]float64{}
+			for _, in := range test.inputs {
+				final[in.tick] = in.input
+			}
+
+			for tick := range uint64(ticks) {
+				if in, ok := final[tick]; ok {
+					mustNoErr(t, ref.SetInput(tick, in))
+				}
+
+				ref.Advance()
+			}
+
+			scene := fecs.NewScene()
+			rollback := fecs.NewRollback(scene, test.window, simulateRollback)
+
+			for tick := range uint64(ticks) {
+				for _, in := range test.inputs {
+					if in.before == tick {
+						mustNoErr(t, rollback.SetInput(in.tick, in.input))
+					}
+				}
+
+				rollback.Advance()
+			}
+
+			if got, want := positionsOf(scene), positionsOf(reference); !slices.Equal(got, want) {
+				t.Errorf("positions = %v, want %v", got, want)
+			}
+
+			if rollback.Tick() != ticks {
+				t.Errorf("Tick() = %d, want %d", rollback.Tick(), ticks)
+			}
+
+			if rollback.Resimulated() != test.resimulated {
+				t.Errorf("Resimulated() = %d, want %d", rollback.Resimulated(), test.resimulated)
+			}
+
+			if v := scene.Validate(); len(v) > 0 {
+				t.Errorf("Validate() = %v", v)
+			}
+		})
+	}
+}
+
+func TestRollbackWindow(t *testing.T) {
+	scene := fecs.NewScene()
+	rollback := fecs.NewRollback(scene, 3, simulateRollback)
+
+	for range 5 {
+		rollback.Advance()
+	}
+
+	if err := rollback.SetInput(1, 1); !errors.Is(err, fecs.ErrRollbackTooOld) {
+		t.Errorf("SetInput() outside the window = %v, want %v", err, fecs.ErrRollbackTooOld)
+	}
+
+	if _, ok := rollback.Input(1); ok {
+		t.Errorf("an input outside the window was stored")
+	}
+
+	mustNoErr(t, rollback.SetInput(2, 4))
+
+	if in, ok := rollback.Input(2); !ok || in != 4 {
+		t.Errorf("Input(2) = %v, %t, want 4, true", in, ok)
+	}
+
+	if steps := rollback.Advance(); steps != 4 {
+		t.Errorf("Advance() = %d, want 4", steps)
+	}
+
+	mustPanic(t, "Rewind() outside the window", func() { rollback.Rewind(1) })
+	mustPanic(t, "Rewind() to the next tick", func() { rollback.Rewind(rollback.Tick()) })
+	mustPanic(t, "NewRollback() with an empty window", func() { fecs.NewRollback(scene, 0, simulateRollback) })
+
+	before := positionsOf(scene)
+	rollback.Rewind(rollback.Tick() - 1)
+	rollback.Advance()
+
+	if got := positionsOf(scene); !slices.Equal(got, before) {
+		t.Errorf("re-simulating a tick gave %v, want %v", got, before)
+	}
+}
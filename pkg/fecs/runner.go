@@ -0,0 +1,191 @@
+package fecs
+
+import (
+	"fmt"
+	"time"
+)
+
+const defaultRunnerMaxSteps = 8
+
+// NewRunner creates a new Runner that updates the given System against the
+// given Scene in fixed steps of the given duration.
+//
+// The returned Runner uses SystemClock, a time scale of 1, and will run at most
+// 8 steps per call to Advance.
+//
+// If the given step duration is not positive, this function will panic.
+func NewRunner(scene Scene, system System, step time.Duration) *Runner {
+	if step <= 0 {
+		panic(fmt.Errorf("runner step duration must be positive, got %s", step))
+	}
+
+	return &Runner{
+		scene:    scene,
+		system:   system,
+		clock:    SystemClock(),
+		step:     step,
+		maxSteps: defaultRunnerMaxSteps,
+		scale:    1,
+	}
+}
+
+// Runner drives a System against a Scene using a fixed simulation timestep.
+//
+// Each call to Advance measures the real time elapsed since the previous call,
+// scales it by the Runner's time scale, and adds it to an accumulator.  The
+// System is then updated once for each whole step in the accumulator.  The
+// remaining fraction of a step is exposed by Alpha for interpolating rendered
+// state between the previous and current simulation states.
+//
+// To avoid falling further and further behind when updates take longer than
+// the step duration (the "spiral of death"), at most MaxSteps steps are run per
+// call to Advance, and any time beyond that is discarded.
+type Runner struct {
+	scene  Scene
+	system System
+	clock  Clock
+
+	step     time.Duration
+	maxSteps int
+	scale    float64
+	paused   bool
+
+	started     bool
+	last        time.Time
+	accumulator time.Duration
+	ticks       uint64
+	dropped     time.Duration
+}
+
+// SetClock replaces the Clock this Runner uses to measure elapsed time.
+//
+// Changing the Clock resets the Runner's elapsed time measurement, so the next
+// call to Advance will not run any steps.
+func (r *Runner) SetClock(clock Clock) {
+	r.clock = clock
+	r.started = false
+}
+
+// SetMaxSteps sets the maximum number of steps that may be run by a single call
+// to Advance.
+//
+// If the given value is less than 1, this method will panic.
+func (r *Runner) SetMaxSteps(steps int) {
+	if steps < 1 {
+		panic(fmt.Errorf("runner max steps must be at least 1, got %d", steps))
+	}
+
+	r.maxSteps = steps
+}
+
+// SetTimeScale sets the factor by which elapsed real time is multiplied before
+// being added to the simulation.
+//
+// A scale of 0.5 runs the simulation at half speed, a scale of 2 runs it at
+// double speed.  If the given value is negative, this method will panic.
+func (r *Runner) SetTimeScale(scale float64) {
+	if scale < 0 {
+		panic(fmt.Errorf("runner time scale must not be negative, got %f", scale))
+	}
+
+	r.scale = scale
+}
+
+// TimeScale returns the factor by which elapsed real time is multiplied before
+// being added to the simulation.
+func (r *Runner) TimeScale() float64 {
+	return r.scale
+}
+
+// Pause stops calls to Advance from running any steps.
+//
+// Real time that elapses while the Runner is paused is not added to the
+// simulation.
+func (r *Runner) Pause() {
+	r.paused = true
+}
+
+// Resume undoes a previous call to Pause.
+func (r *Runner) Resume() {
+	r.paused = false
+}
+
+// IsPaused tests whether this Runner is currently paused.
+func (r *Runner) IsPaused() bool {
+	return r.paused
+}
+
+// Step runs a single fixed step, regardless of whether this Runner is paused
+// and of how much time has been accumulated.
+func (r *Runner) Step() {
+	r.system.Update(r.scene, r.step)
+	r.ticks++
+}
+
+// Advance measures the real time elapsed since the previous call to Advance and
+// runs as many fixed steps as fit into the accumulated simulation time.
+//
+// The first call to Advance only starts measuring time and does not run any
+// steps.
+//
+// Returns the number of steps that were run.
+func (r *Runner) Advance() int {
+	now := r.clock.Now()
+
+	if !r.started {
+		r.started = true
+		r.last = now
+		return 0
+	}
+
+	elapsed := now.Sub(r.last)
+	r.last = now
+
+	if r.paused || elapsed <= 0 {
+		return 0
+	}
+
+	r.accumulator += time.Duration(float64(elapsed) * r.scale)
+
+	steps := 0
+	for r.accumulator >= r.step && steps < r.maxSteps {
+		r.Step()
+		r.accumulator -= r.step
+		steps++
+	}
+
+	// If we hit the step cap, throw away the whole steps we couldn't run so we
+	// don't try to catch up on them next time.
+	if r.accumulator >= r.step {
+		drop := r.accumulator - r.accumulator%r.step
+		r.dropped += drop
+		r.accumulator -= drop
+	}
+
+	return steps
+}
+
+// Alpha returns the fraction of a step currently held in the accumulator, in
+// the range [0, 1).
+//
+// Renderers may use this value to interpolate between the previous and current
+// simulation states.
+func (r *Runner) Alpha() float64 {
+	return float64(r.accumulator) / float64(r.step)
+}
+
+// StepDuration returns the fixed duration of each simulation step.
+func (r *Runner) StepDuration() time.Duration {
+	return r.step
+}
+
+// Ticks returns the total number of steps this Runner has run.
+func (r *Runner) Ticks() uint64 {
+	return r.ticks
+}
+
+// Dropped returns the total amount of simulation time that has been discarded
+// because Advance hit the step limit.
+func (r *Runner) Dropped() time.Duration {
+	return r.dropped
+}
@@ -0,0 +1,142 @@
+package fecs_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+func TestRunnerFixedTimestep(t *testing.T) {
+	const ms = time.Millisecond
+
+	type call struct {
+		advance time.Duration
+		steps   int
+		alpha   float64
+	}
+
+	pause := func(r *fecs.Runner) { r.Pause() }
+
+	tests := []struct {
+		name    string
+		step    time.Duration
+		setup   func(r *fecs.Runner)
+		calls   []call
+		dropped time.Duration
+	}{
+		{"whole steps", 10 * ms, nil, []call{{10 * ms, 1, 0}, {20 * ms, 2, 0}, {30 * ms, 3, 0}}, 0},
+		{"leftover carried over", 16 * ms, nil, []call{{20 * ms, 1, 0.25}, {12 * ms, 1, 0}, {40 * ms, 2, 0.5}}, 0},
+		{"less than a step", 10 * ms, nil, []call{{3 * ms, 0, 0.3}, {3 * ms, 0, 0.6}, {3 * ms, 0, 0.9}, {3 * ms, 1, 0.2}}, 0},
+		{"step cap drops whole steps", 10 * ms, func(r *fecs.Runner) { r.SetMaxSteps(3) }, []call{{95 * ms, 3, 0.5}, {5 * ms, 1, 0}}, 60 * ms},
+		{"default step cap", 1 * ms, nil, []call{{20 * ms, 8, 0}}, 12 * ms},
+		{"half speed", 10 * ms, func(r *fecs.Runner) { r.SetTimeScale(0.5) }, []call{{30 * ms, 1, 0.5}, {10 * ms, 1, 0}}, 0},
+		{"double speed", 10 * ms, func(r *fecs.Runner) { r.SetTimeScale(2) }, []call{{25 * ms, 5, 0}}, 0},
+		{"stopped", 10 * ms, func(r *fecs.Runner) { r.SetTimeScale(0) }, []call{{50 * ms, 0, 0}}, 0},
+		{"paused", 10 * ms, pause, []call{{50 * ms, 0, 0}, {50 * ms, 0, 0}}, 0},
+		{"clock going backwards", 10 * ms, nil, []call{{15 * ms, 1, 0.5}, {-20 * ms, 0, 0.5}, {10 * ms, 1, 0.5}}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := fecstest.NewClock()
+			updates := 0
+
+			runner := fecs.NewRunner(fecs.NewScene(), fecs.NewSystem("count", func(_ fecs.Scene, dt time.Duration) {
+				if dt != test.step {
+					t.Errorf("Update() given dt %s, want %s", dt, test.step)
+				}
+
+				updates++
+			}), test.step)
+
+			runner.SetClock(clock)
+
+			if test.setup != nil {
+				test.setup(runner)
+			}
+
+			if steps := runner.Advance(); steps != 0 {
+				t.Fatalf("first Advance() = %d, want 0", steps)
+			}
+
+			total := 0
+
+			for i, c := range test.calls {
+				clock.Advance(c.advance)
+				total += c.steps
+
+				if steps := runner.Advance(); steps != c.steps {
+					t.Errorf("call %d: Advance() = %d, want %d", i, steps, c.steps)
+				}
+
+				if alpha := runner.Alpha(); math.Abs(alpha-c.alpha) > 1e-9 {
+					t.Errorf("call %d: Alpha() = %v, want %v", i, alpha, c.alpha)
+				}
+			}
+
+			if updates != total || runner.Ticks() != uint64(total) {
+				t.Errorf("ran %d updates over %d ticks, want %d", updates, runner.Ticks(), total)
+			}
+
+			if runner.Dropped() != test.dropped {
+				t.Errorf("Dropped() = %s, want %s", runner.Dropped(), test.dropped)
+			}
+		})
+	}
+}
+
+func TestRunnerControls(t *testing.T) {
+	clock := fecstest.NewClock()
+	runner := fecs.NewRunner(fecs.NewScene(), fecs.NewSystem("noop", func(fecs.Scene, time.Duration) {}), 10*time.Millisecond)
+	runner.SetClock(clock)
+	runner.Advance()
+
+	// Time that passes while paused is not added on Resume.
+	runner.Pause()
+	clock.Advance(time.Second)
+	runner.Advance()
+	runner.Resume()
+
+	clock.Advance(10 * time.Millisecond)
+	if steps := runner.Advance(); steps != 1 || runner.IsPaused() {
+		t.Errorf("Advance() after Resume() = %d, want 1", steps)
+	}
+
+	// Step runs even while paused.
+	runner.Pause()
+	runner.Step()
+	if runner.Ticks() != 2 || !runner.IsPaused() {
+		t.Errorf("Ticks() after Step() = %d, want 2", runner.Ticks())
+	}
+	runner.Resume()
+
+	// Changing the Clock restarts time measurement.
+	other := fecstest.NewClock()
+	other.Advance(time.Hour)
+	runner.SetClock(other)
+
+	if steps := runner.Advance(); steps != 0 {
+		t.Errorf("first Advance() with a new Clock = %d, want 0", steps)
+	}
+
+	// An auto-advancing clock moves on by one step per Advance.
+	other.SetStep(10 * time.Millisecond)
+	runner.Advance()
+
+	for i := range 3 {
+		if steps := runner.Advance(); steps != 1 {
+			t.Errorf("auto-advance %d: Advance() = %d, want 1", i, steps)
+		}
+	}
+
+	if runner.StepDuration() != 10*time.Millisecond || runner.TimeScale() != 1 {
+		t.Errorf("StepDuration(), TimeScale() = %s, %v", runner.StepDuration(), runner.TimeScale())
+	}
+
+	mustPanic(t, "NewRunner() with a zero step", func() { fecs.NewRunner(fecs.NewScene(), nil, 0) })
+	mustPanic(t, "SetMaxSteps(0)", func() { runner.SetMaxSteps(0) })
+	mustPanic(t, "SetTimeScale(-1)", func() { runner.SetTimeScale(-1) })
+}
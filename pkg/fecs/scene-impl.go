@@ -1,7 +1,9 @@
 package fecs
 
 import (
+	"errors"
 	"fmt"
+	"iter"
 	"strconv"
 
 	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
@@ -14,78 +16,342 @@ func NewScene() Scene {
 	return &scene{
 		sceneID:    sceneID,
 		entities:   newEntityPool(),
-		components: make(map[ComponentType]*componentPool, 16),
+		components: make(map[ComponentType]componentStorage, 16),
 	}
 }
 
 type scene struct {
 	sceneID    SceneID
 	entities   entityPool
-	components map[ComponentType]*componentPool
+	components map[ComponentType]componentStorage
+	listeners  []sceneListener
 }
 
 func (s *scene) ID() SceneID {
 	return s.sceneID
 }
 
+func (s *scene) Stats() SceneStats {
+	return sceneStats(s)
+}
+
+func (s *scene) Compact() *Remapping {
+	out := newRemapping()
+
+	for _, pool := range s.components {
+		pool.compact(out.components)
+	}
+
+	s.entities.compact(out.entities)
+
+	// Point the living entities at the new IDs of any moved components.
+	if len(out.components) > 0 {
+		for i := uint32(0); i < s.entities.size; i++ {
+			for _, ref := range s.entities.pool[i].comps {
+				if cid, ok := out.components[*ref]; ok {
+					*ref = cid
+				}
+			}
+		}
+	}
+
+	for _, l := range s.listeners {
+		l.sceneCompacted(out)
+	}
+
+	s.checkInvariants("Compact")
+
+	return out
+}
+
 func (s *scene) ContainsEntity(id *EntityID) bool {
 	return s.entities.containsEntity(id)
 }
 
 func (s *scene) DestroyEntity(id *EntityID) bool {
-	// If the target entity is not in this scene, return false as we aren't
+	return s.TryDestroyEntity(id) == nil
+}
+
+func (s *scene) TryDestroyEntity(id *EntityID) error {
+	// If the target entity is not in this scene, return an error as we aren't
 	// removing it.
-	if !s.entities.containsEntity(id) {
-		return false
+	if err := s.checkEntity(id); err != nil {
+		return err
+	}
+
+	// Only bother recording the removed components if someone is listening.
+	var removed []componentRecord
+	if len(s.listeners) > 0 {
+		removed = make([]componentRecord, 0, len(s.entities.getEntityComponents(id)))
 	}
 
-	// Grab all the component ids attached to the target entity.
-	comps := s.entities.getEntityComponents(id)
+	s.destroyEntity(id, removed)
+	s.checkInvariants("DestroyEntity")
 
-	// Remove the entity's components from the component pools.
-	for _, ref := range comps {
-		if pool, ok := s.components[ref.ctype]; ok {
-			pool.removeComponent(ref)
+	return nil
+}
+
+func (s *scene) DestroyBatch(ids []EntityID) int {
+	// When someone is listening, the records of every removed component are
+	// carved out of one shared backing slice rather than allocated per entity.
+	var records []componentRecord
+	if len(s.listeners) > 0 {
+		total := 0
+		for i := range ids {
+			if s.checkEntity(&ids[i]) == nil {
+				total += len(s.entities.getEntityComponents(&ids[i]))
+			}
 		}
+
+		records = make([]componentRecord, 0, total)
 	}
 
-	// kill the entity.
-	s.entities.removeEntity(id)
+	count := 0
 
-	// return true because we did remove the entity from the scene.
-	return true
+	for i := range ids {
+		// Skips invalid IDs, as well as IDs that appear more than once.
+		if s.checkEntity(&ids[i]) != nil {
+			continue
+		}
+
+		var removed []componentRecord
+		if records != nil {
+			start := len(records)
+			end := start + len(s.entities.getEntityComponents(&ids[i]))
+			removed = records[start:start:end]
+			records = records[:end]
+		}
+
+		s.destroyEntity(&ids[i], removed)
+		count++
+	}
+
+	if count > 0 {
+		s.checkInvariants("DestroyBatch")
+	}
+
+	return count
 }
 
 func (s *scene) Entities(ct ...ComponentType) futil.Iterator[EntityID] {
 	return s.entities.entities(ct)
 }
 
+func (s *scene) EachEntity(ct ...ComponentType) iter.Seq[EntityID] {
+	// The iterator is created per range so that the sequence may be ranged over
+	// more than once, and always sees the current entity pool.
+	return func(yield func(EntityID) bool) {
+		for id := range futil.Seq(s.entities.entities(ct)) {
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+func (s *scene) Each(ct ComponentType, with ...ComponentType) iter.Seq2[EntityID, Component] {
+	mask := componentMask{}
+	mask.add(ct)
+
+	for _, t := range with {
+		mask.add(t)
+	}
+
+	return func(yield func(EntityID, Component) bool) {
+		it := entityIterator{pool: s.entities.pool}
+
+		for it.HasNext() {
+			ent := it.Next()
+
+			if !ent.mask.hasAll(&mask) {
+				continue
+			}
+
+			comp, ok := s.GetComponentByType(&ent.id, ct)
+			if !ok {
+				panic("illegal state")
+			}
+
+			if !yield(ent.id, comp) {
+				return
+			}
+		}
+	}
+}
+
+func (s *scene) Query(q *Query) futil.Iterator[EntityID] {
+	return s.entities.query(q)
+}
+
+func (s *scene) EachQuery(q *Query) iter.Seq[EntityID] {
+	return func(yield func(EntityID) bool) {
+		for id := range futil.Seq(s.entities.query(q)) {
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
+
 func (s *scene) NewEntity() EntityID {
-	return s.entities.newEntity(s.sceneID)
+	id := s.entities.newEntity(s.sceneID)
+
+	for _, l := range s.listeners {
+		l.entityCreated(&id)
+	}
+
+	s.checkInvariants("NewEntity")
+
+	return id
+}
+
+func (s *scene) SpawnBatch(n int, constructors ...ComponentConstructor) []EntityID {
+	if n <= 0 {
+		return nil
+	}
+
+	width := len(constructors)
+
+	// Build every component up front, so that the batch is rejected before any
+	// entities are created if the constructors disagree about types.
+	//
+	// The constructed components are also what listeners are given.  Values held
+	// by a Store may not be read back out of the pool for this, as listeners may
+	// keep them after the slot is reused.
+	made := make([]Component, n*width)
+	types := make([]ComponentType, width)
+	mask := componentMask{}
+
+	for j, ctor := range constructors {
+		made[j] = ctor()
+		types[j] = made[j].Type()
+
+		if mask.has(types[j]) {
+			panic(fmt.Errorf("attempted to spawn entities with multiple components of type %s", types[j].String()))
+		}
+
+		mask.add(types[j])
+	}
+
+	for k := width; k < len(made); k++ {
+		j := k % width
+		made[k] = constructors[j]()
+
+		if ct := made[k].Type(); ct != types[j] {
+			panic(fmt.Errorf("attempted to spawn entities with a constructor that created components of both type %s and type %s: %w", types[j].String(), ct.String(), ErrWrongComponentType))
+		}
+	}
+
+	pools := make([]componentStorage, width)
+	for j, ct := range types {
+		pools[j] = s.storage(ct)
+		pools[j].reserve(uint32(n))
+	}
+
+	s.entities.reserve(uint32(n))
+
+	out := make([]EntityID, n)
+
+	// Every entity's component references are carved out of one shared backing
+	// slice rather than allocated individually.
+	cids := make([]ComponentID, n*width)
+	refs := make([]*ComponentID, n*width)
+
+	for i := range out {
+		out[i] = s.entities.newEntity(s.sceneID)
+		ent := &s.entities.pool[out[i].index]
+
+		for _, l := range s.listeners {
+			l.entityCreated(&out[i])
+		}
+
+		// The entity's mask and component references are grown one component at
+		// a time, so that listeners see the same intermediate states they would
+		// for a series of AttachComponent calls.
+		for j := range constructors {
+			k := i*width + j
+			cids[k] = pools[j].newComponent(made[k])
+			refs[k] = &cids[k]
+
+			ent.mask.add(types[j])
+			ent.comps = refs[i*width : k+1 : (i+1)*width]
+
+			for _, l := range s.listeners {
+				l.componentAttached(&out[i], refs[k], made[k])
+			}
+		}
+	}
+
+	s.checkInvariants("SpawnBatch")
+
+	return out
 }
 
 func (s *scene) AttachComponent(id *EntityID, constructor ComponentConstructor) ComponentID {
-	if !s.entities.containsEntity(id) {
-		panic(fmt.Errorf("attempted to attach a new component to an entity (%s) which is not currently registered to the target scene (%s)", id.String(), s.String()))
+	cid, err := s.TryAttachComponent(id, constructor)
+	if err != nil {
+		panic(err)
+	}
+
+	return cid
+}
+
+func (s *scene) TryAttachComponent(id *EntityID, constructor ComponentConstructor) (ComponentID, error) {
+	if err := s.checkEntity(id); err != nil {
+		return ComponentID{}, fmt.Errorf("cannot attach component: %w", err)
 	}
 
 	comp := constructor()
 
-	if pool, ok := s.components[comp.Type()]; ok {
-		cid := pool.newComponent(comp)
-		s.entities.addComponent(id, &cid)
-		return cid
+	if s.entities.entityHasComponentType(id, comp.Type()) {
+		return ComponentID{}, fmt.Errorf("cannot attach component of type %s to entity %s: %w", comp.Type().String(), id.String(), ErrDuplicateComponent)
 	}
 
-	pool := newComponentPool()
-	s.components[comp.Type()] = pool
+	return s.attachComponent(id, comp), nil
+}
 
-	cid := pool.newComponent(comp)
-	s.entities.addComponent(id, &cid)
+func (s *scene) AttachComponentByType(id *EntityID, ct ComponentType, constructor ComponentConstructor) ComponentID {
+	cid, err := s.TryAttachComponentByType(id, ct, constructor)
+	if err != nil {
+		panic(err)
+	}
 
 	return cid
 }
 
+func (s *scene) TryAttachComponentByType(id *EntityID, ct ComponentType, constructor ComponentConstructor) (ComponentID, error) {
+	if err := s.checkEntity(id); err != nil {
+		return ComponentID{}, fmt.Errorf("cannot attach component: %w", err)
+	}
+
+	if s.entities.entityHasComponentType(id, ct) {
+		return ComponentID{}, fmt.Errorf("cannot attach component of type %s to entity %s: %w", ct.String(), id.String(), ErrDuplicateComponent)
+	}
+
+	comp := constructor()
+
+	if comp.Type() != ct {
+		return ComponentID{}, fmt.Errorf("cannot attach component of type %s as a component of type %s: %w", comp.Type().String(), ct.String(), ErrWrongComponentType)
+	}
+
+	return s.attachComponent(id, comp), nil
+}
+
+func (s *scene) Components(id *EntityID) []ComponentID {
+	if !s.entities.containsEntity(id) {
+		return nil
+	}
+
+	refs := s.entities.getEntityComponents(id)
+
+	out := make([]ComponentID, len(refs))
+	for i := range refs {
+		out[i] = *refs[i]
+	}
+
+	return out
+}
+
 func (s *scene) GetComponent(cid *ComponentID) (Component, bool) {
 	if pool, ok := s.components[cid.ctype]; ok {
 		return pool.getComponent(cid)
@@ -94,32 +360,258 @@ func (s *scene) GetComponent(cid *ComponentID) (Component, bool) {
 	return nil, false
 }
 
+func (s *scene) TryGetComponent(cid *ComponentID) (Component, error) {
+	if comp, ok := s.GetComponent(cid); ok {
+		return comp, nil
+	}
+
+	return nil, fmt.Errorf("component %s: %w", cid.String(), ErrComponentNotFound)
+}
+
 func (s *scene) GetComponentByType(eid *EntityID, ct ComponentType) (Component, bool) {
-	if id, ok := s.entities.getEntityComponent(eid, ct); ok {
-		if pool, ok := s.components[ct]; ok {
-			if comp, ok := pool.getComponent(id); ok {
-				return comp, true
-			}
+	comp, err := s.TryGetComponentByType(eid, ct)
 
-			panic("illegal state")
-		}
+	if errors.Is(err, ErrIllegalState) {
+		panic(err)
+	}
+
+	return comp, err == nil
+}
 
-		panic("illegal state")
+func (s *scene) TryGetComponentByType(eid *EntityID, ct ComponentType) (Component, error) {
+	if err := s.checkEntity(eid); err != nil {
+		return nil, err
 	}
 
-	return nil, false
+	id, ok := s.entities.getEntityComponent(eid, ct)
+	if !ok {
+		return nil, fmt.Errorf("component of type %s on entity %s: %w", ct.String(), eid.String(), ErrComponentNotFound)
+	}
+
+	if pool, ok := s.components[ct]; ok {
+		if comp, ok := pool.getComponent(id); ok {
+			return comp, nil
+		}
+	}
+
+	return nil, fmt.Errorf("entity %s references missing component %s: %w", eid.String(), id.String(), ErrIllegalState)
 }
 
 func (s *scene) HasComponent(eid *EntityID, cid *ComponentID) bool {
-	// TODO implement me
-	panic("implement me")
+	if !s.entities.containsEntity(eid) {
+		return false
+	}
+
+	return s.entities.pool[eid.index].hasComponent(cid)
 }
 
 func (s *scene) RemoveComponent(eid *EntityID, cid *ComponentID) bool {
-	// TODO implement me
-	panic("implement me")
+	return s.TryRemoveComponent(eid, cid) == nil
+}
+
+func (s *scene) TryRemoveComponent(eid *EntityID, cid *ComponentID) error {
+	if err := s.checkEntity(eid); err != nil {
+		return err
+	}
+
+	if !s.entities.removeComponent(eid, cid) {
+		return fmt.Errorf("component %s on entity %s: %w", cid.String(), eid.String(), ErrComponentNotFound)
+	}
+
+	pool := s.components[cid.ctype]
+
+	if len(s.listeners) == 0 {
+		pool.removeComponent(cid)
+		s.checkInvariants("RemoveComponent")
+		return nil
+	}
+
+	comp, _ := pool.takeComponent(cid)
+
+	for _, l := range s.listeners {
+		l.componentRemoved(eid, cid, comp)
+	}
+
+	s.checkInvariants("RemoveComponent")
+
+	return nil
+}
+
+func (s *scene) ReplaceComponent(cid *ComponentID, constructor ComponentConstructor) bool {
+	err := s.TryReplaceComponent(cid, constructor)
+
+	if errors.Is(err, ErrWrongComponentType) {
+		panic(err)
+	}
+
+	return err == nil
+}
+
+func (s *scene) TryReplaceComponent(cid *ComponentID, constructor ComponentConstructor) error {
+	pool, ok := s.components[cid.ctype]
+	if !ok || !pool.containsComponent(cid) {
+		return fmt.Errorf("component %s: %w", cid.String(), ErrComponentNotFound)
+	}
+
+	comp := constructor()
+
+	if comp.Type() != cid.ctype {
+		return fmt.Errorf("cannot replace component %s with a component of type %s: %w", cid.String(), comp.Type().String(), ErrWrongComponentType)
+	}
+
+	s.setComponent(cid, comp)
+
+	return nil
 }
 
 func (s *scene) String() string {
 	return "scene-" + strconv.FormatUint(uint64(s.sceneID), 16)
 }
+
+// checkEntity returns an error if the entity identified by the given EntityID
+// is not in this scene.
+func (s *scene) checkEntity(id *EntityID) error {
+	if id.scene != s.sceneID {
+		return fmt.Errorf("entity %s is not in scene %s: %w", id.String(), s.String(), ErrWrongScene)
+	}
+
+	if !s.entities.containsEntity(id) {
+		return fmt.Errorf("entity %s is not in scene %s: %w", id.String(), s.String(), ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+// attachComponent attaches the given Component to the entity identified by the
+// given EntityID, which must be in this scene and must not already have a
+// component of the same type, and notifies listeners.
+func (s *scene) attachComponent(id *EntityID, comp Component) ComponentID {
+	cid := s.storage(comp.Type()).newComponent(comp)
+	s.entities.addComponent(id, &cid)
+
+	for _, l := range s.listeners {
+		l.componentAttached(id, &cid, comp)
+	}
+
+	s.checkInvariants("AttachComponent")
+
+	return cid
+}
+
+// destroyEntity removes the entity identified by the given EntityID, which must
+// be in this scene, along with all of its components, and notifies listeners.
+//
+// If the given slice is not nil, the removed components are appended to it
+// before it is passed to listeners; it must have enough capacity to hold every
+// component of the entity.
+func (s *scene) destroyEntity(id *EntityID, removed []componentRecord) {
+	// Remove the entity's components from the component pools.
+	for _, ref := range s.entities.getEntityComponents(id) {
+		if pool, ok := s.components[ref.ctype]; ok {
+			if removed != nil {
+				comp, _ := pool.takeComponent(ref)
+				removed = append(removed, componentRecord{*ref, comp})
+			} else {
+				pool.removeComponent(ref)
+			}
+		}
+	}
+
+	// kill the entity.
+	s.entities.removeEntity(id)
+
+	for _, l := range s.listeners {
+		l.entityDestroyed(id, removed)
+	}
+}
+
+// storage returns the componentStorage for the given ComponentType, creating a
+// componentPool for it if it does not yet exist.
+func (s *scene) storage(ct ComponentType) componentStorage {
+	if pool, ok := s.components[ct]; ok {
+		return pool
+	}
+
+	pool := newComponentPool()
+	s.components[ct] = pool
+
+	return pool
+}
+
+// restoreEntity brings back an entity with exactly the given EntityID.
+//
+// Returns false if the target slot is already occupied.
+func (s *scene) restoreEntity(id *EntityID) bool {
+	if id.scene != s.sceneID || !s.entities.restoreEntity(id) {
+		return false
+	}
+
+	for _, l := range s.listeners {
+		l.entityCreated(id)
+	}
+
+	s.checkInvariants("restoring an entity")
+
+	return true
+}
+
+// restoreComponent re-attaches the given Component to the target entity under
+// exactly the given ComponentID.
+//
+// Returns false if the target entity does not exist, already has a component
+// of the same type, or if the target component slot is already occupied.
+func (s *scene) restoreComponent(eid *EntityID, cid *ComponentID, comp Component) bool {
+	if !s.entities.containsEntity(eid) || s.entities.entityHasComponentType(eid, cid.ctype) {
+		return false
+	}
+
+	if !s.storage(cid.ctype).restoreComponent(cid, comp) {
+		return false
+	}
+
+	ref := *cid
+	s.entities.addComponent(eid, &ref)
+
+	for _, l := range s.listeners {
+		l.componentAttached(eid, cid, comp)
+	}
+
+	s.checkInvariants("restoring a component")
+
+	return true
+}
+
+// setComponent swaps the value stored under the given ComponentID without
+// type checking the new value.
+func (s *scene) setComponent(cid *ComponentID, comp Component) bool {
+	pool, ok := s.components[cid.ctype]
+	if !ok {
+		return false
+	}
+
+	old, ok := pool.replaceComponent(cid, comp)
+	if !ok {
+		return false
+	}
+
+	for _, l := range s.listeners {
+		l.componentReplaced(cid, old, comp)
+	}
+
+	s.checkInvariants("ReplaceComponent")
+
+	return true
+}
+
+func (s *scene) addListener(l sceneListener) {
+	s.listeners = append(s.listeners, l)
+}
+
+func (s *scene) removeListener(l sceneListener) {
+	for i := range s.listeners {
+		if s.listeners[i] == l {
+			s.listeners = append(s.listeners[:i], s.listeners[i+1:]...)
+			return
+		}
+	}
+}
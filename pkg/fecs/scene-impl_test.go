@@ -0,0 +1,304 @@
+package fecs_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+func TestSceneEachEntity(t *testing.T) {
+	scene := fecs.NewScene()
+	moving := scene.SpawnBatch(2, newPosition(0, 0), newVelocity(1, 1))
+	still := scene.SpawnBatch(1, newPosition(0, 0))
+
+	tests := []struct {
+		name  string
+		types []fecs.ComponentType
+		want  []fecs.EntityID
+	}{
+		{"all", nil, append(slices.Clone(moving), still...)},
+		{"one type", []fecs.ComponentType{positionType}, append(slices.Clone(moving), still...)},
+		{"two types", []fecs.ComponentType{positionType, velocityType}, moving},
+		{"no match", []fecs.ComponentType{healthType}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seq := scene.EachEntity(test.types...)
+
+			for i := range 2 {
+				if got := slices.Collect(seq); !slices.Equal(got, test.want) {
+					t.Errorf("range %d: got %v, want %v", i+1, got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSceneEachEntitySeesGrownPool(t *testing.T) {
+	scene := fecs.NewScene()
+	seq := scene.EachEntity()
+
+	// Grow the entity pool well past its initial capacity after the sequence
+	// was created.
+	ids := scene.SpawnBatch(100)
+
+	if got := slices.Collect(seq); !slices.Equal(got, ids) {
+		t.Errorf("got %d entities, want %d", len(got), len(ids))
+	}
+}
+
+func TestSceneEachEntityStopsEarly(t *testing.T) {
+	scene := fecs.NewScene()
+	scene.SpawnBatch(10)
+
+	seen := 0
+	for range scene.EachEntity() {
+		seen++
+		if seen == 3 {
+			break
+		}
+	}
+
+	if seen != 3 {
+		t.Errorf("seen = %d, want 3", seen)
+	}
+}
+
+func TestSceneSpawnBatch(t *testing.T) {
+	scene := fecs.NewScene()
+	calls := 0
+	ids := scene.SpawnBatch(50, newPosition(1, 2), func() fecs.Component {
+		calls++
+		return &Health{calls}
+	})
+
+	if len(ids) != 50 || calls != 50 {
+		t.Fatalf("spawned %d entities with %d constructor calls, want 50 and 50", len(ids), calls)
+	}
+
+	fecstest.AssertEntityCount(t, scene, 50, positionType, healthType)
+
+	for i := range ids {
+		if comp, _ := scene.GetComponentByType(&ids[i], healthType); comp.(*Health).Points != i+1 {
+			t.Fatalf("entity %d has Health %d, want %d", i, comp.(*Health).Points, i+1)
+		}
+	}
+
+	if scene.SpawnBatch(0, newPosition(0, 0)) != nil {
+		t.Error("expected spawning 0 entities to return nil")
+	}
+
+	if v := scene.Validate(); len(v) > 0 {
+		t.Errorf("Validate() = %v", v)
+	}
+}
+
+func TestSceneSpawnBatchObservers(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter []fecs.ComponentType
+		// seen is the number of components an entity should have when it enters
+		// the filter.
+		seen int
+	}{
+		{"empty filter", nil, 0},
+		{"first component", []fecs.ComponentType{positionType}, 1},
+		{"second component", []fecs.ComponentType{velocityType}, 2},
+		{"both components", []fecs.ComponentType{positionType, velocityType}, 2},
+		{"unrelated component", []fecs.ComponentType{healthType}, -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene := fecs.NewScene()
+			observer := fecs.NewObserver(scene, test.filter...)
+			observer.SetBuffered(true)
+
+			observer.OnEnter(func(s fecs.Scene, id fecs.EntityID) {
+				if got := len(s.Components(&id)); got != test.seen {
+					t.Errorf("entity %v entered with %d components, want %d", id, got, test.seen)
+				}
+			})
+
+			ids := scene.SpawnBatch(3, newPosition(0, 0), newVelocity(0, 0))
+
+			var want []fecs.ObserverEvent
+			if test.seen >= 0 {
+				for _, id := range ids {
+					want = append(want, fecs.ObserverEvent{Kind: fecs.ObserverEnter, Entity: id})
+				}
+			}
+
+			if got := observer.Events(); !slices.Equal(got, want) {
+				t.Errorf("Events() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSceneSpawnBatchRejectsBadConstructors(t *testing.T) {
+	tests := []struct {
+		name         string
+		constructors []fecs.ComponentConstructor
+		wantErr      error
+	}{
+		{"duplicate types", []fecs.ComponentConstructor{newPosition(0, 0), newPosition(1, 1)}, nil},
+		{"inconsistent type", []fecs.ComponentConstructor{newVelocity(0, 0), func() func() fecs.Component {
+			calls := 0
+			return func() fecs.Component {
+				if calls++; calls == 3 {
+					return &Health{}
+				}
+				return &Position{}
+			}
+		}()}, fecs.ErrWrongComponentType},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene := fecs.NewScene()
+
+			func() {
+				defer func() {
+					r := recover()
+					if r == nil {
+						t.Fatal("expected SpawnBatch to panic")
+					}
+
+					if err, _ := r.(error); test.wantErr != nil && !errors.Is(err, test.wantErr) {
+						t.Errorf("panic = %v, want %v", r, test.wantErr)
+					}
+				}()
+
+				scene.SpawnBatch(5, test.constructors...)
+			}()
+
+			fecstest.AssertEntityCount(t, scene, 0)
+
+			if stats := scene.Stats(); len(stats.Components) != 0 {
+				t.Errorf("expected no component pools to be created, got %d", len(stats.Components))
+			}
+		})
+	}
+}
+
+func TestSceneDestroyBatch(t *testing.T) {
+	scene := fecs.NewScene()
+	journal := fecs.NewJournal(scene)
+	ids := scene.SpawnBatch(6, newPosition(0, 0))
+	scene.AttachComponent(&ids[1], newHealth(10))
+	journal.Clear()
+
+	dead := scene.NewEntity()
+	scene.DestroyEntity(&dead)
+	other := fecs.NewScene().NewEntity()
+
+	// Duplicate, destroyed and foreign IDs are skipped.
+	targets := []fecs.EntityID{ids[0], ids[1], ids[1], dead, other, ids[4]}
+
+	if got := scene.DestroyBatch(targets); got != 3 {
+		t.Fatalf("DestroyBatch() = %d, want 3", got)
+	}
+
+	fecstest.AssertEntityCount(t, scene, 3)
+	fecstest.AssertEntityCount(t, scene, 0, healthType)
+
+	if v := scene.Validate(); len(v) > 0 {
+		t.Fatalf("Validate() = %v", v)
+	}
+
+	// Undo every recorded destruction, checking that the components recorded for
+	// each entity were not overwritten by those of the others.
+	for journal.CanUndo() {
+		journal.Undo()
+	}
+
+	fecstest.AssertEntityCount(t, scene, 6)
+	fecstest.AssertHasComponent(t, scene, ids[1], healthType)
+
+	if comp, ok := scene.GetComponentByType(&ids[1], healthType); !ok || comp.(*Health).Points != 10 {
+		t.Errorf("restored Health = %v, %t, want 10, true", comp, ok)
+	}
+
+	if scene.DestroyBatch(nil) != 0 {
+		t.Error("expected DestroyBatch(nil) to return 0")
+	}
+}
+
+func TestSceneTryAttachComponentByType(t *testing.T) {
+	scene := fecs.NewScene()
+	id := scene.NewEntity()
+	scene.AttachComponent(&id, newPosition(0, 0))
+
+	gone := scene.NewEntity()
+	scene.DestroyEntity(&gone)
+
+	foreign := fecs.NewScene().NewEntity()
+
+	tests := []struct {
+		name      string
+		id        fecs.EntityID
+		ct        fecs.ComponentType
+		make      fecs.ComponentConstructor
+		wantErr   error
+		wantCalls int
+	}{
+		{"attaches", id, velocityType, newVelocity(1, 1), nil, 1},
+		{"duplicate", id, positionType, newPosition(1, 1), fecs.ErrDuplicateComponent, 0},
+		{"destroyed entity", gone, velocityType, newVelocity(1, 1), fecs.ErrEntityNotFound, 0},
+		{"foreign entity", foreign, velocityType, newVelocity(1, 1), fecs.ErrWrongScene, 0},
+		{"wrong type", id, healthType, newVelocity(1, 1), fecs.ErrWrongComponentType, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			ctor := func() fecs.Component {
+				calls++
+				return test.make()
+			}
+
+			_, err := scene.TryAttachComponentByType(&test.id, test.ct, ctor)
+
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("error = %v, want %v", err, test.wantErr)
+			}
+
+			if calls != test.wantCalls {
+				t.Errorf("constructor called %d times, want %d", calls, test.wantCalls)
+			}
+		})
+	}
+
+	fecstest.AssertHasComponent(t, scene, id, velocityType)
+	fecstest.AssertNoComponent(t, scene, id, healthType)
+
+	if v := scene.Validate(); len(v) > 0 {
+		t.Errorf("Validate() = %v", v)
+	}
+}
+
+func TestSceneTryAttachComponent(t *testing.T) {
+	scene := fecs.NewScene()
+	id := scene.NewEntity()
+
+	if _, err := scene.TryAttachComponent(&id, newPosition(0, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := scene.TryAttachComponent(&id, newPosition(1, 1)); !errors.Is(err, fecs.ErrDuplicateComponent) {
+		t.Errorf("error = %v, want %v", err, fecs.ErrDuplicateComponent)
+	}
+
+	scene.DestroyEntity(&id)
+
+	calls := 0
+	_, err := scene.TryAttachComponent(&id, func() fecs.Component { calls++; return &Position{} })
+	if !errors.Is(err, fecs.ErrEntityNotFound) || calls != 0 {
+		t.Errorf("error = %v with %d constructor calls, want %v with 0", err, calls, fecs.ErrEntityNotFound)
+	}
+}
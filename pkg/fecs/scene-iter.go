@@ -0,0 +1,24 @@
+package fecs
+
+import "iter"
+
+// EachOf returns an iter.Seq2 over the entities in the given Scene that have a
+// Component of the given ComponentType, as well as Components of all the other
+// given ComponentTypes, attached.
+//
+// Each entity is yielded along with its Component of the first given
+// ComponentType, asserted to type T.  If that Component is not of type T, this
+// function will panic.
+//
+//	for id, pos := range fecs.EachOf[*Position](scene, PositionType) {
+//		...
+//	}
+func EachOf[T Component](scene Scene, ct ComponentType, with ...ComponentType) iter.Seq2[EntityID, T] {
+	return func(yield func(EntityID, T) bool) {
+		for id, comp := range scene.Each(ct, with...) {
+			if !yield(id, comp.(T)) {
+				return
+			}
+		}
+	}
+}
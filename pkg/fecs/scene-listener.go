@@ -0,0 +1,40 @@
+package fecs
+
+// sceneListener defines an internal observer of the structural changes made to
+// a scene.
+//
+// Listener methods are called after the change they describe has been applied
+// to the scene.  The pointer arguments are only valid for the duration of the
+// call; implementations that need to hold on to an ID must copy it.
+type sceneListener interface {
+	// entityCreated is called when a new entity is created in, or restored to, a
+	// scene.
+	entityCreated(eid *EntityID)
+
+	// entityDestroyed is called when an entity is removed from a scene.
+	//
+	// The given slice contains the components that were attached to the entity
+	// at the time it was destroyed.  componentRemoved is not called for these
+	// components.
+	entityDestroyed(eid *EntityID, comps []componentRecord)
+
+	// componentAttached is called when a component is attached to an entity.
+	componentAttached(eid *EntityID, cid *ComponentID, comp Component)
+
+	// componentRemoved is called when a component is detached from an entity.
+	componentRemoved(eid *EntityID, cid *ComponentID, comp Component)
+
+	// componentReplaced is called when the value stored for a component is
+	// swapped out for a new value.
+	componentReplaced(cid *ComponentID, old, new Component)
+
+	// sceneCompacted is called when a scene's pools are compacted, moving
+	// entities and components to new IDs.
+	sceneCompacted(remap *Remapping)
+}
+
+// componentRecord pairs a ComponentID with the Component value it identified.
+type componentRecord struct {
+	id   ComponentID
+	comp Component
+}
@@ -0,0 +1,195 @@
+package fecs
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ComponentCloner may be implemented by Components that need control over how
+// they are copied when a SceneState is captured or restored.
+//
+// Components that do not implement ComponentCloner are copied shallowly: a
+// Component held by pointer is copied by allocating a new value and copying
+// the pointed-to value into it, any other Component is copied by assignment.
+// Components holding slices, maps or other references that must not be shared
+// between copies should implement ComponentCloner.
+type ComponentCloner interface {
+	// Clone returns an independent copy of this Component.
+	Clone() Component
+}
+
+// CaptureState captures the full current state of the given Scene, including
+// the exact EntityIDs, ComponentIDs and free slots in use, so that it may later
+// be put back with RestoreState.
+//
+// Every Component in the Scene is copied, see ComponentCloner.
+//
+// The given Scene must have been created by NewScene, otherwise this function
+// will panic.
+func CaptureState(s Scene) *SceneState {
+	out := new(SceneState)
+	out.capture(requireSceneImpl(s, "capture the state of"))
+	return out
+}
+
+// RestoreState puts the given Scene back into the state recorded by the given
+// SceneState.
+//
+// The SceneState is left unchanged and may be restored again later.
+//
+// Restoring a SceneState is not reported as individual changes to Journals,
+// Observers, ReplicationSources or Metrics attached to the Scene.
+//
+// If the given Scene was not created by NewScene, or is not the Scene the given
+// SceneState was captured from, this function will panic.
+func RestoreState(s Scene, state *SceneState) {
+	impl := requireSceneImpl(s, "restore the state of")
+
+	if impl.sceneID != state.sceneID {
+		panic(fmt.Errorf("attempted to restore state captured from scene-%x into scene %s", state.sceneID, impl.String()))
+	}
+
+	state.restore(impl)
+	impl.checkInvariants("RestoreState")
+}
+
+// SceneState is an in-memory copy of the full state of a Scene, as returned by
+// CaptureState.
+type SceneState struct {
+	sceneID    SceneID
+	entities   entityPool
+	components map[ComponentType]componentStorage
+}
+
+// capture copies the state of the given scene into this SceneState, reusing the
+// memory already held by this SceneState where possible.
+func (s *SceneState) capture(from *scene) {
+	s.sceneID = from.sceneID
+	copyEntityPool(&s.entities, &from.entities)
+
+	if s.components == nil {
+		s.components = make(map[ComponentType]componentStorage, len(from.components))
+	}
+
+	for ct := range s.components {
+		if _, ok := from.components[ct]; !ok {
+			delete(s.components, ct)
+		}
+	}
+
+	for ct, pool := range from.components {
+		s.components[ct] = pool.copyTo(s.components[ct])
+	}
+}
+
+// restore copies this SceneState into the given scene.
+func (s *SceneState) restore(into *scene) {
+	copyEntityPool(&into.entities, &s.entities)
+
+	// Stores are emptied rather than dropped so that any Store handles pointing
+	// at them remain attached to the scene.
+	for ct, pool := range into.components {
+		if _, ok := s.components[ct]; !ok {
+			pool.reset()
+		}
+	}
+
+	for ct, pool := range s.components {
+		into.components[ct] = pool.copyTo(into.components[ct])
+	}
+}
+
+// copyEntityPool deep copies the src entityPool into the dst entityPool.
+func copyEntityPool(dst, src *entityPool) {
+	if cap(dst.pool) >= len(src.pool) {
+		dst.pool = dst.pool[:len(src.pool)]
+		clear(dst.pool[src.size:])
+	} else {
+		dst.pool = make([]entity, len(src.pool))
+	}
+
+	// Count the attached components so their IDs can share one allocation.
+	total := 0
+	for i := uint32(0); i < src.size; i++ {
+		total += len(src.pool[i].comps)
+	}
+
+	ids := make([]ComponentID, 0, total)
+
+	for i := uint32(0); i < src.size; i++ {
+		from, to := &src.pool[i], &dst.pool[i]
+
+		to.id = from.id
+		to.mask = from.mask
+		to.comps = nil
+
+		if len(from.comps) > 0 {
+			to.comps = make([]*ComponentID, len(from.comps), max(len(from.comps), 8))
+
+			for j, ref := range from.comps {
+				ids = append(ids, *ref)
+				to.comps[j] = &ids[len(ids)-1]
+			}
+		}
+	}
+
+	dst.free = src.free.Copy()
+	dst.size = src.size
+	dst.allocs = src.allocs
+	dst.floor = src.floor
+}
+
+// copyComponentPool deep copies the src componentPool into the dst
+// componentPool, cloning each live Component.
+func copyComponentPool(dst, src *componentPool) {
+	if cap(dst.ids) >= len(src.ids) {
+		dst.ids = dst.ids[:len(src.ids)]
+		dst.pool = dst.pool[:len(src.pool)]
+	} else {
+		dst.ids = make([]ComponentID, len(src.ids))
+		dst.pool = make([]Component, len(src.pool))
+	}
+
+	copy(dst.ids, src.ids)
+
+	for i := range src.pool {
+		if src.pool[i] == nil {
+			dst.pool[i] = nil
+		} else {
+			dst.pool[i] = cloneComponent(src.pool[i])
+		}
+	}
+
+	dst.free = src.free.Copy()
+	dst.size = src.size
+	dst.allocs = src.allocs
+	dst.floor = src.floor
+}
+
+// cloneComponent returns a copy of the given Component, see ComponentCloner.
+func cloneComponent(comp Component) Component {
+	if cloner, ok := comp.(ComponentCloner); ok {
+		return cloner.Clone()
+	}
+
+	value := reflect.ValueOf(comp)
+
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		out := reflect.New(value.Elem().Type())
+		out.Elem().Set(value.Elem())
+		return out.Interface().(Component)
+	}
+
+	return comp
+}
+
+// requireSceneImpl returns the given Scene as a *scene, panicking if it was not
+// created by NewScene.
+func requireSceneImpl(s Scene, action string) *scene {
+	impl, ok := s.(*scene)
+	if !ok {
+		panic(fmt.Errorf("cannot %s scene %s, only scenes created by NewScene are supported", action, s.String()))
+	}
+
+	return impl
+}
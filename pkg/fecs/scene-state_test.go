@@ -0,0 +1,147 @@
+package fecs_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+var inventoryType = fecs.NewNamedComponentType("Inventory")
+
+// Inventory holds a slice, so it must implement fecs.ComponentCloner to be
+// captured safely.
+type Inventory struct {
+	Items []string
+}
+
+func (*Inventory) Type() fecs.ComponentType {
+	return inventoryType
+}
+
+func (i *Inventory) Clone() fecs.Component {
+	return &Inventory{slices.Clone(i.Items)}
+}
+
+func TestSceneStateRestore(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(s fecs.Scene, ids fecstest.Entities)
+	}{
+		{"nothing", func(fecs.Scene, fecstest.Entities) {}},
+		{"new entities", func(s fecs.Scene, _ fecstest.Entities) {
+			s.SpawnBatch(20, newPosition(1, 1))
+		}},
+		{"destroy entity", func(s fecs.Scene, ids fecstest.Entities) {
+			id := ids["player"]
+			s.DestroyEntity(&id)
+		}},
+		{"attach new component type", func(s fecs.Scene, ids fecstest.Entities) {
+			id := ids["wall"]
+			s.AttachComponent(&id, newFrozen)
+		}},
+		{"remove component", func(s fecs.Scene, ids fecstest.Entities) {
+			removeComponent(s, ids["player"], velocityType)
+		}},
+		{"mutate in place", func(s fecs.Scene, ids fecstest.Entities) {
+			id := ids["player"]
+			pos, _ := s.GetComponentByType(&id, positionType)
+			pos.(*Position).X = 99
+
+			inv, _ := s.GetComponentByType(&id, inventoryType)
+			inv.(*Inventory).Items[0] = "rock"
+		}},
+		{"compact", func(s fecs.Scene, ids fecstest.Entities) {
+			id := ids["ghost"]
+			s.DestroyEntity(&id)
+			s.Compact()
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene, ids := fecstest.NewScene(t, fecstest.Fixture{
+				"ghost":  {&Health{0}},
+				"player": {&Position{1, 2}, &Velocity{1, 0}, &Inventory{[]string{"sword"}}},
+				"wall":   {&Position{10, 0}, &Health{100}},
+			})
+
+			// Leave a free slot behind so the state includes a free list.
+			spare := scene.NewEntity()
+			scene.DestroyEntity(&spare)
+
+			want := snapshotOf(t, scene)
+			state := fecs.CaptureState(scene)
+
+			test.change(scene, ids)
+
+			for i := range 2 {
+				fecs.RestoreState(scene, state)
+
+				if got := snapshotOf(t, scene); got != want {
+					t.Errorf("restore %d: scene is\n%s\nwant\n%s", i+1, got, want)
+				}
+
+				if v := scene.Validate(); len(v) > 0 {
+					t.Errorf("restore %d: Validate() = %v", i+1, v)
+				}
+			}
+		})
+	}
+}
+
+func TestSceneStateRestoresFreeSlots(t *testing.T) {
+	scene := fecs.NewScene()
+	scene.NewEntity()
+
+	spare := scene.NewEntity()
+	scene.DestroyEntity(&spare)
+
+	state := fecs.CaptureState(scene)
+	want := scene.NewEntity()
+
+	scene.SpawnBatch(3, newFrozen)
+	fecs.RestoreState(scene, state)
+
+	if got := scene.NewEntity(); got != want {
+		t.Errorf("NewEntity() after restoring = %v, want %v", got, want)
+	}
+}
+
+func TestSceneStateIsIndependent(t *testing.T) {
+	scene, ids := fecstest.NewScene(t, fecstest.Fixture{
+		"player": {&Position{1, 2}, &Inventory{[]string{"sword"}}},
+	})
+
+	id := ids["player"]
+	state := fecs.CaptureState(scene)
+
+	fecs.RestoreState(scene, state)
+
+	// Mutating the restored components must not change the captured state.
+	inv, _ := scene.GetComponentByType(&id, inventoryType)
+	inv.(*Inventory).Items[0] = "rock"
+
+	pos, _ := scene.GetComponentByType(&id, positionType)
+	pos.(*Position).X = 50
+
+	fecs.RestoreState(scene, state)
+
+	inv, _ = scene.GetComponentByType(&id, inventoryType)
+	pos, _ = scene.GetComponentByType(&id, positionType)
+
+	if got := inv.(*Inventory).Items[0]; got != "sword" {
+		t.Errorf("restored inventory holds %q, want %q", got, "sword")
+	}
+
+	if got := pos.(*Position).X; got != 1 {
+		t.Errorf("restored position has X = %v, want 1", got)
+	}
+}
+
+func TestSceneStateWrongScene(t *testing.T) {
+	state := fecs.CaptureState(fecs.NewScene())
+
+	mustPanic(t, "RestoreState() into another scene", func() { fecs.RestoreState(fecs.NewScene(), state) })
+}
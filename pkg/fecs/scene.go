@@ -2,6 +2,7 @@ package fecs
 
 import (
 	"fmt"
+	"iter"
 
 	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
 )
@@ -14,6 +15,32 @@ type Scene interface {
 	// ID returns the identifier for this Scene instance.
 	ID() SceneID
 
+	// Stats returns a report of the current occupancy and estimated memory use
+	// of this Scene's entity and component pools.
+	Stats() SceneStats
+
+	// Compact packs the entities and Components in this Scene into the front of
+	// their pools, discarding the holes left by destroyed entities and removed
+	// Components, and shrinks the pools to fit.
+	//
+	// Entities and Components that are moved are given new IDs.  The returned
+	// Remapping may be used to update any IDs held outside the Scene; IDs of
+	// entities and Components that were not moved remain valid.  Old IDs of
+	// moved entities and Components are never reused.
+	//
+	// Compacting a Scene clears the history of any attached Journal, and
+	// invalidates any pointers into a Store.
+	Compact() *Remapping
+
+	// Validate checks the internal consistency of this Scene, returning every
+	// violation found.  A consistent Scene returns no violations.
+	//
+	// Validate is intended for debugging and tests; it inspects every entity and
+	// Component in the Scene.  Building with the fecsdebug build tag runs it
+	// after every mutation of a Scene, panicking with a *ValidationError on the
+	// first violation.
+	Validate() []Violation
+
 	// ContainsEntity tests whether this Scene currently contains the entity
 	// identified by the given EntityID.
 	ContainsEntity(id *EntityID) bool
@@ -22,6 +49,25 @@ type Scene interface {
 	// Component instances attached to it.
 	DestroyEntity(id *EntityID) bool
 
+	// TryDestroyEntity removes the target entity from the Scene and unlinks all
+	// the Component instances attached to it.
+	//
+	// Returns an error wrapping ErrWrongScene if the given EntityID was
+	// generated by a different Scene, or ErrEntityNotFound if the target entity
+	// is not in this Scene.
+	TryDestroyEntity(id *EntityID) error
+
+	// DestroyBatch removes all the target entities from the Scene, unlinking all
+	// the Component instances attached to them.
+	//
+	// EntityIDs that do not identify an entity in this Scene are skipped.
+	//
+	// The target entities are removed in a single pass; Journals and other
+	// attached observers still see one destruction per entity.
+	//
+	// Returns the number of entities that were removed.
+	DestroyBatch(ids []EntityID) int
+
 	// Entities returns an Iterator over all the entities in this Scene that have
 	// all Components of all the given ComponentTypes attached to them.
 	//
@@ -35,23 +81,117 @@ type Scene interface {
 	// cause undefined behavior.
 	Entities(componentTypes ...ComponentType) futil.Iterator[EntityID]
 
+	// EachEntity returns an iter.Seq over the EntityIDs of all the entities in
+	// this Scene that have Components of all the given ComponentTypes attached.
+	//
+	// If no ComponentType values are passed to this method, the returned
+	// sequence will yield every entity in this Scene.
+	//
+	// Adding or removing entities from this Scene while ranging over the
+	// returned sequence may cause undefined behavior.
+	EachEntity(componentTypes ...ComponentType) iter.Seq[EntityID]
+
+	// Each returns an iter.Seq2 over the entities in this Scene that have a
+	// Component of the given ComponentType, as well as Components of all the
+	// other given ComponentTypes, attached.
+	//
+	// Each entity is yielded along with its Component of the first given
+	// ComponentType.  See EachOf for a typed alternative.
+	//
+	// Adding or removing entities from this Scene while ranging over the
+	// returned sequence may cause undefined behavior.
+	Each(ct ComponentType, with ...ComponentType) iter.Seq2[EntityID, Component]
+
+	// Query returns an Iterator over all the entities in this Scene whose
+	// attached ComponentTypes satisfy the given Query.
+	//
+	// Adding or removing entities from this Scene while an Iterator is in use may
+	// cause undefined behavior.
+	Query(q *Query) futil.Iterator[EntityID]
+
+	// EachQuery returns an iter.Seq over the EntityIDs of all the entities in
+	// this Scene whose attached ComponentTypes satisfy the given Query.
+	//
+	// Adding or removing entities from this Scene while ranging over the
+	// returned sequence may cause undefined behavior.
+	EachQuery(q *Query) iter.Seq[EntityID]
+
 	// NewEntity creates a new entity in this Scene and returns its EntityID.
 	//
 	// Entities themselves consist of the returned EntityID and a mask of attached
 	// ComponentTypes.
 	NewEntity() EntityID
 
+	// SpawnBatch creates n new entities in this Scene, attaching to each a new
+	// Component created by every one of the given constructors, and returns the
+	// EntityIDs of the new entities.
+	//
+	// Space for the new entities and Components is reserved up front, so
+	// spawning a large number of entities this way is considerably cheaper than
+	// calling NewEntity and AttachComponent for each one.
+	//
+	// Every constructor is called n times before any entities are created.  If
+	// any two of the given constructors create Components of the same
+	// ComponentType, or any one constructor creates Components of more than one
+	// ComponentType, this method will panic before any entities are created.
+	SpawnBatch(n int, constructors ...ComponentConstructor) []EntityID
+
 	// AttachComponent attaches a new Component created by the given constructor
 	// to the entity identified by the given EntityID.
 	//
-	// If the target entity is not found in this Scene, this method will panic.
-	// ContainsEntity should be called before this method to ensure that this
-	// method will succeed.
+	// If the target entity is not found in this Scene, or already has a
+	// Component of the same ComponentType attached, this method will panic.
+	// TryAttachComponent may be used instead to receive an error in these cases.
 	//
 	// This method returns the ComponentID generated for the new Component
 	// created by the given ComponentConstructor.
 	AttachComponent(id *EntityID, constructor ComponentConstructor) ComponentID
 
+	// TryAttachComponent attaches a new Component created by the given
+	// constructor to the entity identified by the given EntityID.
+	//
+	// The ComponentType of the new Component is not known until the constructor
+	// has been called, so the constructor is called even when the target entity
+	// already has a Component of that type attached.  TryAttachComponentByType
+	// may be used instead for constructors that have side effects.
+	//
+	// Returns an error wrapping ErrWrongScene or ErrEntityNotFound if the target
+	// entity is not in this Scene, or ErrDuplicateComponent if the target entity
+	// already has a Component of the same ComponentType attached.
+	TryAttachComponent(id *EntityID, constructor ComponentConstructor) (ComponentID, error)
+
+	// AttachComponentByType attaches a new Component of the given ComponentType,
+	// created by the given constructor, to the entity identified by the given
+	// EntityID.
+	//
+	// If the target entity is not found in this Scene, already has a Component
+	// of the given ComponentType attached, or the constructor creates a Component
+	// of a different ComponentType, this method will panic.
+	// TryAttachComponentByType may be used instead to receive an error in these
+	// cases.
+	AttachComponentByType(id *EntityID, ct ComponentType, constructor ComponentConstructor) ComponentID
+
+	// TryAttachComponentByType attaches a new Component of the given
+	// ComponentType, created by the given constructor, to the entity identified
+	// by the given EntityID.
+	//
+	// The target entity is checked before the constructor is called, so the
+	// constructor is not called at all if the Component would be rejected for
+	// any reason other than its ComponentType.
+	//
+	// Returns an error wrapping ErrWrongScene or ErrEntityNotFound if the target
+	// entity is not in this Scene, ErrDuplicateComponent if the target entity
+	// already has a Component of the given ComponentType attached, or
+	// ErrWrongComponentType if the constructor creates a Component of a
+	// different ComponentType.
+	TryAttachComponentByType(id *EntityID, ct ComponentType, constructor ComponentConstructor) (ComponentID, error)
+
+	// Components returns the ComponentIDs of all the Components attached to the
+	// entity identified by the given EntityID.
+	//
+	// If the target entity is not found in this Scene, this method returns nil.
+	Components(id *EntityID) []ComponentID
+
 	// GetComponent attempts to look up a Component identified by the given
 	// ComponentID from this Scene.
 	//
@@ -62,6 +202,13 @@ type Scene interface {
 	// the target Component and true.
 	GetComponent(cid *ComponentID) (Component, bool)
 
+	// TryGetComponent attempts to look up a Component identified by the given
+	// ComponentID from this Scene.
+	//
+	// Returns an error wrapping ErrComponentNotFound if the target Component
+	// could not be found in this Scene.
+	TryGetComponent(cid *ComponentID) (Component, error)
+
 	// GetComponentByType attempts to look up a Component with the given
 	// ComponentType attached to a target entity identified by the given EntityID.
 	//
@@ -70,8 +217,20 @@ type Scene interface {
 	//
 	// If the target entity does have a Component of the given ComponentType, this
 	// method will return the located Component and true.
+	//
+	// If the internal state of this Scene is found to be inconsistent, this
+	// method will panic.
 	GetComponentByType(eid *EntityID, ct ComponentType) (Component, bool)
 
+	// TryGetComponentByType attempts to look up a Component with the given
+	// ComponentType attached to a target entity identified by the given EntityID.
+	//
+	// Returns an error wrapping ErrWrongScene or ErrEntityNotFound if the target
+	// entity is not in this Scene, ErrComponentNotFound if the target entity has
+	// no Component of the given ComponentType, or ErrIllegalState if the internal
+	// state of this Scene is found to be inconsistent.
+	TryGetComponentByType(eid *EntityID, ct ComponentType) (Component, error)
+
 	// HasComponent tests whether the entity identified by the given EntityID has
 	// the target Component attached to it.
 	HasComponent(eid *EntityID, cid *ComponentID) bool
@@ -82,4 +241,32 @@ type Scene interface {
 	// Returns a boolean value indicating whether the target Component was
 	// attached to the target entity before this method was called.
 	RemoveComponent(eid *EntityID, cid *ComponentID) bool
+
+	// TryRemoveComponent attempts to remove the target component from the entity
+	// identified by the given EntityID.
+	//
+	// Returns an error wrapping ErrWrongScene or ErrEntityNotFound if the target
+	// entity is not in this Scene, or ErrComponentNotFound if the target
+	// Component is not attached to the target entity.
+	TryRemoveComponent(eid *EntityID, cid *ComponentID) error
+
+	// ReplaceComponent swaps the value of the Component identified by the given
+	// ComponentID for a new Component created by the given constructor.
+	//
+	// The ComponentID of the target Component is unchanged by this operation.
+	//
+	// If the new Component is not of the same ComponentType as the target
+	// Component, this method will panic.
+	//
+	// Returns a boolean value indicating whether the target Component was found
+	// in this Scene.
+	ReplaceComponent(cid *ComponentID, constructor ComponentConstructor) bool
+
+	// TryReplaceComponent swaps the value of the Component identified by the
+	// given ComponentID for a new Component created by the given constructor.
+	//
+	// Returns an error wrapping ErrComponentNotFound if the target Component was
+	// not found in this Scene, or ErrWrongComponentType if the new Component is
+	// not of the same ComponentType as the target Component.
+	TryReplaceComponent(cid *ComponentID, constructor ComponentConstructor) error
 }
@@ -0,0 +1,284 @@
+package fecs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	snapshotFormat  = "fecs-snapshot"
+	snapshotVersion = 2
+)
+
+// Snapshot is a serializable, point-in-time copy of the contents of a Scene.
+//
+// Component values are stored in their JSON form as produced by encoding/json,
+// meaning that Snapshots may be read and inspected without access to the
+// Component implementations they were taken from.
+type Snapshot struct {
+	Format   string           `json:"format"`
+	Version  int              `json:"version"`
+	Scene    SceneID          `json:"scene"`
+	Entities []SnapshotEntity `json:"entities"`
+}
+
+// SnapshotEntity is the Snapshot representation of a single entity.
+type SnapshotEntity struct {
+	// ID holds the stringified EntityID of the entity.
+	ID string `json:"id"`
+
+	// Components holds the Components attached to the entity.
+	Components []SnapshotComponent `json:"components"`
+}
+
+// SnapshotComponent is the Snapshot representation of a single Component.
+type SnapshotComponent struct {
+	// ID holds the stringified ComponentID of the Component.
+	ID string `json:"id"`
+
+	// Type holds the ComponentType of the Component.
+	Type ComponentType `json:"type"`
+
+	// Name holds the name of the Component's ComponentType at the time the
+	// Snapshot was taken.
+	Name string `json:"name"`
+
+	// Schema holds the schema version of the Component's ComponentType at the
+	// time the Snapshot was taken, or 0 if no ComponentSchema was registered for
+	// it.
+	Schema int `json:"schema,omitempty"`
+
+	// Value holds the JSON form of the Component.
+	Value json.RawMessage `json:"value"`
+}
+
+// TakeSnapshot creates a new Snapshot of the current contents of the given
+// Scene.
+//
+// Returns an error if any of the Components in the given Scene cannot be
+// marshaled to JSON.
+func TakeSnapshot(scene Scene) (*Snapshot, error) {
+	out := &Snapshot{
+		Format:   snapshotFormat,
+		Version:  snapshotVersion,
+		Scene:    scene.ID(),
+		Entities: make([]SnapshotEntity, 0, 32),
+	}
+
+	for it := scene.Entities(); it.HasNext(); {
+		eid := it.Next()
+		cids := scene.Components(&eid)
+
+		ent := SnapshotEntity{
+			ID:         eid.String(),
+			Components: make([]SnapshotComponent, 0, len(cids)),
+		}
+
+		for i := range cids {
+			comp, ok := scene.GetComponent(&cids[i])
+			if !ok {
+				panic("illegal state")
+			}
+
+			value, err := json.Marshal(comp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal component %s of entity %s: %w", cids[i].String(), eid.String(), err)
+			}
+
+			ent.Components = append(ent.Components, SnapshotComponent{
+				ID:     cids[i].String(),
+				Type:   cids[i].ctype,
+				Name:   cids[i].ctype.Name(),
+				Schema: componentSchemaVersion(cids[i].ctype),
+				Value:  value,
+			})
+		}
+
+		out.Entities = append(out.Entities, ent)
+	}
+
+	return out, nil
+}
+
+// LoadSnapshot creates a copy of every entity in the given Snapshot, along with
+// its Components, in the given Scene.
+//
+// Components are reconstructed using the ComponentSchema registered for their
+// ComponentType with RegisterComponentSchema, upgrading them from the schema
+// version they were written with to the current schema version along the way.
+// The ComponentType of each Component is looked up by name where possible,
+// falling back to the ComponentType number recorded in the Snapshot.
+//
+// Loaded entities are given new EntityIDs.  This function returns a map of the
+// EntityIDs recorded in the Snapshot to the EntityIDs of the loaded entities.
+//
+// If any Component of an entity cannot be loaded, that entity is skipped and
+// the remaining entities are still loaded.  The errors for all the skipped
+// entities are returned together as a *SnapshotLoadError.
+func LoadSnapshot(scene Scene, snap *Snapshot) (map[EntityID]EntityID, error) {
+	out := make(map[EntityID]EntityID, len(snap.Entities))
+	var failed []*EntityLoadError
+
+	for i := range snap.Entities {
+		ent := &snap.Entities[i]
+
+		old, comps, err := ent.load()
+		if err != nil {
+			failed = append(failed, &EntityLoadError{Entity: ent.ID, Err: err})
+			continue
+		}
+
+		id := scene.NewEntity()
+
+		for _, comp := range comps {
+			if _, err = scene.TryAttachComponent(&id, func() Component { return comp }); err != nil {
+				break
+			}
+		}
+
+		if err != nil {
+			scene.DestroyEntity(&id)
+			failed = append(failed, &EntityLoadError{Entity: ent.ID, Err: err})
+			continue
+		}
+
+		out[old] = id
+	}
+
+	if len(failed) > 0 {
+		return out, &SnapshotLoadError{Entities: failed}
+	}
+
+	return out, nil
+}
+
+// SnapshotLoadError is returned by LoadSnapshot when one or more entities could
+// not be loaded.
+type SnapshotLoadError struct {
+	Entities []*EntityLoadError
+}
+
+func (e *SnapshotLoadError) Error() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("failed to load %d entities from scene snapshot", len(e.Entities)))
+
+	for _, err := range e.Entities {
+		sb.WriteString("\n\t")
+		sb.WriteString(err.Error())
+	}
+
+	return sb.String()
+}
+
+func (e *SnapshotLoadError) Unwrap() []error {
+	out := make([]error, len(e.Entities))
+	for i := range e.Entities {
+		out[i] = e.Entities[i]
+	}
+
+	return out
+}
+
+// EntityLoadError records the reason a single entity could not be loaded from
+// a Snapshot.
+type EntityLoadError struct {
+	// Entity holds the stringified EntityID of the entity as recorded in the
+	// Snapshot.
+	Entity string
+
+	Err error
+}
+
+func (e *EntityLoadError) Error() string {
+	return "entity " + e.Entity + ": " + e.Err.Error()
+}
+
+func (e *EntityLoadError) Unwrap() error {
+	return e.Err
+}
+
+// ReadSnapshot reads a Snapshot previously written by Snapshot.Write from the
+// given io.Reader.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	out := new(Snapshot)
+
+	if err := json.NewDecoder(r).Decode(out); err != nil {
+		return nil, fmt.Errorf("failed to read scene snapshot: %w", err)
+	}
+
+	if out.Format != snapshotFormat {
+		return nil, fmt.Errorf("failed to read scene snapshot: unrecognized format %q", out.Format)
+	}
+
+	if out.Version < 1 || out.Version > snapshotVersion {
+		return nil, fmt.Errorf("failed to read scene snapshot: unsupported version %d", out.Version)
+	}
+
+	return out, nil
+}
+
+// Write writes this Snapshot to the given io.Writer.
+func (s *Snapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// Entity looks up the SnapshotEntity with the given EntityID.
+func (s *Snapshot) Entity(id *EntityID) (*SnapshotEntity, bool) {
+	str := id.String()
+
+	for i := range s.Entities {
+		if s.Entities[i].ID == str {
+			return &s.Entities[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// load parses the EntityID of this SnapshotEntity and reconstructs all of its
+// Components.
+func (e *SnapshotEntity) load() (EntityID, []Component, error) {
+	id, err := ParseEntityID(e.ID)
+	if err != nil {
+		return EntityID{}, nil, err
+	}
+
+	comps := make([]Component, len(e.Components))
+	var errs []error
+
+	for i := range e.Components {
+		sc := &e.Components[i]
+
+		ct, ok := ComponentTypeByName(sc.Name)
+		if !ok {
+			ct = sc.Type
+		}
+
+		if comps[i], err = loadComponent(ct, sc.Schema, sc.Value); err != nil {
+			errs = append(errs, fmt.Errorf("component %s: %w", sc.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return id, nil, errors.Join(errs...)
+	}
+
+	return id, comps, nil
+}
+
+// Component looks up the SnapshotComponent of the given ComponentType attached
+// to this SnapshotEntity.
+func (e *SnapshotEntity) Component(ct ComponentType) (*SnapshotComponent, bool) {
+	for i := range e.Components {
+		if e.Components[i].Type == ct {
+			return &e.Components[i], true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,226 @@
+package fecs
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unsafe"
+)
+
+const (
+	sizeOfEntity      = uint64(unsafe.Sizeof(entity{}))
+	sizeOfComponentID = uint64(unsafe.Sizeof(ComponentID{}))
+	sizeOfComponent   = uint64(unsafe.Sizeof(Component(nil)))
+	sizeOfPointer     = uint64(unsafe.Sizeof(uintptr(0)))
+	sizeOfFreeSlot    = uint64(unsafe.Sizeof(uint32(0)))
+)
+
+// SceneStats is a point-in-time report of the occupancy and estimated memory
+// use of a Scene, as returned by Scene.Stats.
+type SceneStats struct {
+	Scene      string           `json:"scene"`
+	Entities   PoolStats        `json:"entities"`
+	Components []ComponentStats `json:"components"`
+}
+
+// Bytes returns the estimated total number of bytes held by the Scene.
+func (s *SceneStats) Bytes() uint64 {
+	out := s.Entities.Bytes
+
+	for i := range s.Components {
+		out += s.Components[i].Bytes
+	}
+
+	return out
+}
+
+// String renders this report as a human-readable table, one pool per line.
+func (s *SceneStats) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(s.Scene)
+	sb.WriteString(": ")
+	sb.WriteString(FormatBytes(s.Bytes()))
+	sb.WriteByte('\n')
+
+	tw := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	tw.Write([]byte("POOL\tLIVE\tSIZE\tCAPACITY\tFREE\tFRAGMENTATION\tBYTES\n"))
+
+	writePoolStats(tw, "entities", &s.Entities)
+	for i := range s.Components {
+		writePoolStats(tw, s.Components[i].Name, &s.Components[i].PoolStats)
+	}
+
+	tw.Flush()
+
+	return sb.String()
+}
+
+// PoolStats describes the occupancy and estimated memory use of a single pool.
+type PoolStats struct {
+	// Live is the number of values currently in use.
+	Live uint32 `json:"live"`
+
+	// Size is the number of pool slots that have ever been used.
+	Size uint32 `json:"size"`
+
+	// Capacity is the number of slots allocated for the pool.
+	Capacity uint32 `json:"capacity"`
+
+	// Free is the length of the pool's free list, which is the number of holes
+	// left in the pool by removed values.
+	Free uint32 `json:"free"`
+
+	// Bytes is the estimated number of bytes held by the pool, including the
+	// values it references.
+	Bytes uint64 `json:"bytes"`
+}
+
+// Fragmentation returns the fraction of the used slots in the pool that are
+// holes, from 0 (fully packed) to 1 (empty).
+func (p *PoolStats) Fragmentation() float64 {
+	if p.Size == 0 {
+		return 0
+	}
+
+	return float64(p.Free) / float64(p.Size)
+}
+
+// Occupancy returns the fraction of the allocated slots in the pool that hold
+// live values.
+func (p *PoolStats) Occupancy() float64 {
+	if p.Capacity == 0 {
+		return 0
+	}
+
+	return float64(p.Live) / float64(p.Capacity)
+}
+
+// ComponentStats describes the occupancy and estimated memory use of the pool
+// for a single ComponentType.
+type ComponentStats struct {
+	Type ComponentType `json:"type"`
+	Name string        `json:"name"`
+	PoolStats
+}
+
+// FormatBytes renders the given number of bytes as a human-readable string
+// using binary units, e.g. "1.5 KiB".
+func FormatBytes(n uint64) string {
+	const units = "KMGTPE"
+
+	if n < 1024 {
+		return strconv.FormatUint(n, 10) + " B"
+	}
+
+	value := float64(n)
+	unit := -1
+
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	return strconv.FormatFloat(value, 'f', 1, 64) + " " + units[unit:unit+1] + "iB"
+}
+
+func writePoolStats(tw *tabwriter.Writer, name string, p *PoolStats) {
+	tw.Write([]byte(name + "\t" +
+		strconv.FormatUint(uint64(p.Live), 10) + "\t" +
+		strconv.FormatUint(uint64(p.Size), 10) + "\t" +
+		strconv.FormatUint(uint64(p.Capacity), 10) + "\t" +
+		strconv.FormatUint(uint64(p.Free), 10) + "\t" +
+		strconv.FormatFloat(p.Fragmentation()*100, 'f', 1, 64) + "%\t" +
+		FormatBytes(p.Bytes) + "\n"))
+}
+
+// sceneStats builds a SceneStats report for the given scene.
+func sceneStats(s *scene) SceneStats {
+	out := SceneStats{
+		Scene:      s.String(),
+		Components: make([]ComponentStats, 0, len(s.components)),
+	}
+
+	attached := uint64(0)
+
+	for ct, pool := range s.components {
+		stats := pool.stats()
+		attached += uint64(stats.Live)
+
+		out.Components = append(out.Components, ComponentStats{
+			Type:      ct,
+			Name:      ct.Name(),
+			PoolStats: stats,
+		})
+	}
+
+	sort.Slice(out.Components, func(i, j int) bool { return out.Components[i].Type < out.Components[j].Type })
+
+	out.Entities = s.entities.stats(attached)
+
+	return out
+}
+
+// stats returns the occupancy of this entityPool.
+//
+// Each live entity holds its own slice of ComponentID references, which are
+// estimated from the given total number of attached components.
+func (e *entityPool) stats(attached uint64) PoolStats {
+	free := uint32(e.free.Size())
+
+	return PoolStats{
+		Live:     e.size - free,
+		Size:     e.size,
+		Capacity: uint32(len(e.pool)),
+		Free:     free,
+		Bytes: uint64(len(e.pool))*sizeOfEntity +
+			attached*(sizeOfPointer+sizeOfComponentID) +
+			uint64(free)*sizeOfFreeSlot,
+	}
+}
+
+// stats returns the occupancy of this componentPool.
+//
+// The memory held by the Components themselves is estimated from the size of
+// the first live Component in the pool.
+func (c *componentPool) stats() PoolStats {
+	free := uint32(c.free.Size())
+	live := c.size - free
+
+	out := PoolStats{
+		Live:     live,
+		Size:     c.size,
+		Capacity: uint32(len(c.pool)),
+		Free:     free,
+		Bytes: uint64(len(c.ids))*sizeOfComponentID +
+			uint64(len(c.pool))*sizeOfComponent +
+			uint64(free)*sizeOfFreeSlot,
+	}
+
+	for i := uint32(0); i < c.size; i++ {
+		if c.pool[i] != nil {
+			out.Bytes += uint64(live) * componentValueSize(c.pool[i])
+			break
+		}
+	}
+
+	return out
+}
+
+// componentValueSize returns the number of bytes of the value held by the given
+// Component interface value.
+func componentValueSize(comp Component) uint64 {
+	t := reflect.TypeOf(comp)
+
+	if t.Kind() == reflect.Pointer {
+		return uint64(t.Elem().Size())
+	}
+
+	// Values that do not fit in an interface word are boxed.
+	if size := uint64(t.Size()); size > sizeOfPointer {
+		return size
+	}
+
+	return 0
+}
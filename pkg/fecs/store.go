@@ -0,0 +1,453 @@
+package fecs
+
+import (
+	"fmt"
+	"iter"
+	"unsafe"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
+)
+
+// NewStore creates a value-typed Store for Components of type T in the given
+// Scene.
+//
+// Components kept in a Store are held as contiguous values of T rather than as
+// individually allocated interface values, and are handed out as pointers into
+// the Store.  The ComponentType of the Store is the ComponentType returned by
+// the Type method of *T, which must not depend on the value of T.
+//
+//	positions := fecs.NewStore[Position](scene)
+//	positions.Attach(&id, Position{X: 1, Y: 2})
+//
+// Once a Store exists, every Component of its ComponentType attached to the
+// Scene is kept in it, including Components attached through the Scene
+// methods, which are copied into the Store by value.  Components returned by
+// the Scene for the ComponentType of the Store are pointers of type *T into the
+// Store.
+//
+// Pointers into a Store are only valid until the next Component is added to
+// the Store, or until the Component they point to is removed.
+//
+// A Store must be created before any Component of its ComponentType has been
+// attached to the given Scene.  If Components of the ComponentType have already
+// been attached, or the given Scene was not created by NewScene, this function
+// will panic.  Calling NewStore more than once for the same Scene and type
+// returns handles to the same Store.
+func NewStore[T any, PT interface {
+	*T
+	Component
+}](s Scene) *Store[T, PT] {
+	impl := requireSceneImpl(s, "create a component store in")
+	ct := PT(new(T)).Type()
+
+	switch existing := impl.components[ct].(type) {
+	case nil:
+		pool := newValuePool[T, PT](ct)
+		impl.components[ct] = pool
+		return &Store[T, PT]{impl, pool}
+
+	case *valuePool[T, PT]:
+		return &Store[T, PT]{impl, existing}
+
+	default:
+		panic(fmt.Errorf("attempted to create a store for component type %s in scene %s after components of that type were attached", ct.String(), impl.String()))
+	}
+}
+
+// Store holds all the Components of a single ComponentType in a Scene as
+// contiguous values of T.  See NewStore.
+type Store[T any, PT interface {
+	*T
+	Component
+}] struct {
+	scene *scene
+	pool  *valuePool[T, PT]
+}
+
+// Type returns the ComponentType of the Components held in this Store.
+func (s *Store[T, PT]) Type() ComponentType {
+	return s.pool.ctype
+}
+
+// Len returns the number of Components currently held in this Store.
+func (s *Store[T, PT]) Len() int {
+	return int(s.pool.componentCount())
+}
+
+// Attach copies the given value into this Store and attaches it to the entity
+// identified by the given EntityID.
+//
+// Returns an error wrapping ErrWrongScene or ErrEntityNotFound if the target
+// entity is not in the Scene, or ErrDuplicateComponent if the target entity
+// already has a Component of the same ComponentType attached.
+func (s *Store[T, PT]) Attach(id *EntityID, value T) (ComponentID, error) {
+	if err := s.scene.checkEntity(id); err != nil {
+		return ComponentID{}, fmt.Errorf("cannot attach component: %w", err)
+	}
+
+	if s.scene.entities.entityHasComponentType(id, s.pool.ctype) {
+		return ComponentID{}, fmt.Errorf("cannot attach component of type %s to entity %s: %w", s.pool.ctype.String(), id.String(), ErrDuplicateComponent)
+	}
+
+	cid := s.pool.newValue(&value)
+	s.scene.entities.addComponent(id, &cid)
+
+	// Listeners are given the caller's copy of the value rather than a pointer
+	// into the pool, as they may hold on to it after the slot is reused.
+	for _, l := range s.scene.listeners {
+		l.componentAttached(id, &cid, PT(&value))
+	}
+
+	s.scene.checkInvariants("Store.Attach")
+
+	return cid, nil
+}
+
+// Get returns a pointer to the Component identified by the given ComponentID.
+//
+// If the target Component is not in this Store, this method returns nil and
+// false.
+func (s *Store[T, PT]) Get(cid *ComponentID) (*T, bool) {
+	if cid.ctype != s.pool.ctype || !s.pool.containsComponent(cid) {
+		return nil, false
+	}
+
+	return &s.pool.values[cid.index], true
+}
+
+// GetByEntity returns a pointer to the Component of the type held by this Store
+// attached to the entity identified by the given EntityID.
+//
+// If the target entity is not in the Scene, or has no Component of the type
+// held by this Store attached, this method returns nil and false.
+func (s *Store[T, PT]) GetByEntity(eid *EntityID) (*T, bool) {
+	if eid.scene != s.scene.sceneID {
+		return nil, false
+	}
+
+	cid, ok := s.scene.entities.getEntityComponent(eid, s.pool.ctype)
+	if !ok {
+		return nil, false
+	}
+
+	return s.Get(cid)
+}
+
+// Each returns an iter.Seq2 over the entities in the Scene that have a
+// Component in this Store, as well as Components of all the given
+// ComponentTypes, attached.
+//
+// Each entity is yielded along with a pointer to its Component in this Store.
+//
+// Adding or removing entities or Components while ranging over the returned
+// sequence may cause undefined behavior.
+func (s *Store[T, PT]) Each(with ...ComponentType) iter.Seq2[EntityID, *T] {
+	mask := componentMask{}
+	mask.add(s.pool.ctype)
+
+	for _, t := range with {
+		mask.add(t)
+	}
+
+	return func(yield func(EntityID, *T) bool) {
+		it := entityIterator{pool: s.scene.entities.pool}
+
+		for it.HasNext() {
+			ent := it.Next()
+
+			if !ent.mask.hasAll(&mask) {
+				continue
+			}
+
+			value, ok := s.GetByEntity(&ent.id)
+			if !ok {
+				panic(fmt.Errorf("entity %s references missing component of type %s: %w", ent.id.String(), s.pool.ctype.String(), ErrIllegalState))
+			}
+
+			if !yield(ent.id, value) {
+				return
+			}
+		}
+	}
+}
+
+func newValuePool[T any, PT interface {
+	*T
+	Component
+}](ct ComponentType) *valuePool[T, PT] {
+	return &valuePool[T, PT]{
+		ctype:  ct,
+		free:   futil.NewStack[uint32](),
+		ids:    make([]ComponentID, componentPoolInitialCapacity),
+		values: make([]T, componentPoolInitialCapacity),
+		allocs: 1,
+	}
+}
+
+// valuePool is a componentStorage implementation that holds its Components as
+// contiguous values of T.
+type valuePool[T any, PT interface {
+	*T
+	Component
+}] struct {
+	ctype  ComponentType
+	free   futil.Stack[uint32]
+	ids    []ComponentID
+	values []T
+	size   uint32
+
+	// allocs counts the number of times the backing slices have been allocated.
+	allocs uint32
+
+	// floor is the highest version that was in use by any slot discarded by
+	// compaction.
+	floor uint32
+}
+
+func (v *valuePool[T, PT]) componentCount() uint32 {
+	return v.size - uint32(v.free.Size())
+}
+
+func (v *valuePool[T, PT]) containsComponent(id *ComponentID) bool {
+	return id.index < v.size && v.ids[id.index].Equals(id)
+}
+
+func (v *valuePool[T, PT]) getComponent(id *ComponentID) (Component, bool) {
+	if !v.containsComponent(id) {
+		return nil, false
+	}
+
+	return PT(&v.values[id.index]), true
+}
+
+func (v *valuePool[T, PT]) newComponent(comp Component) ComponentID {
+	return v.newValue(v.unwrap(comp))
+}
+
+// newValue copies the given value into the pool and returns its new
+// ComponentID.
+func (v *valuePool[T, PT]) newValue(value *T) ComponentID {
+	var idx uint32
+
+	if v.free.IsEmpty() {
+		v._ensureCapacity(v.size + 1)
+		idx = v.size
+		v.ids[idx] = ComponentID{index: ^idx, version: v.floor}
+		v.size++
+	} else {
+		idx = v.free.Pop()
+	}
+
+	v.ids[idx].init(idx, v.ctype)
+	v.values[idx] = *value
+
+	return v.ids[idx]
+}
+
+func (v *valuePool[T, PT]) removeComponent(id *ComponentID) bool {
+	if !v.containsComponent(id) {
+		return false
+	}
+
+	v.ids[id.index].clear()
+
+	var zero T
+	v.values[id.index] = zero
+	v.free.Push(id.index)
+
+	return true
+}
+
+func (v *valuePool[T, PT]) takeComponent(id *ComponentID) (Component, bool) {
+	if !v.containsComponent(id) {
+		return nil, false
+	}
+
+	out := new(T)
+	*out = v.values[id.index]
+	v.removeComponent(id)
+
+	return PT(out), true
+}
+
+func (v *valuePool[T, PT]) replaceComponent(id *ComponentID, comp Component) (Component, bool) {
+	if !v.containsComponent(id) {
+		return nil, false
+	}
+
+	old := new(T)
+	*old = v.values[id.index]
+	v.values[id.index] = *v.unwrap(comp)
+
+	return PT(old), true
+}
+
+func (v *valuePool[T, PT]) restoreComponent(id *ComponentID, comp Component) bool {
+	if id.index > v.size {
+		return false
+	}
+
+	if id.index == v.size {
+		v._ensureCapacity(v.size + 1)
+		v.size++
+	} else if v.ids[id.index].isActive(id.index) {
+		return false
+	} else {
+		removeFromStack(v.free, id.index)
+	}
+
+	v.ids[id.index] = *id
+	v.values[id.index] = *v.unwrap(comp)
+
+	return true
+}
+
+func (v *valuePool[T, PT]) reserve(n uint32) {
+	if free := uint32(v.free.Size()); n > free {
+		v._ensureCapacity(v.size + n - free)
+	}
+}
+
+func (v *valuePool[T, PT]) reset() {
+	for i := uint32(0); i < v.size; i++ {
+		v.floor = max(v.floor, v.ids[i].version)
+	}
+
+	v.free = futil.NewStack[uint32]()
+	clear(v.ids)
+	clear(v.values)
+	v.size = 0
+}
+
+func (v *valuePool[T, PT]) compact(moved map[ComponentID]ComponentID) {
+	live := uint32(0)
+
+	for i := uint32(0); i < v.size; i++ {
+		if !v.ids[i].isActive(i) {
+			continue
+		}
+
+		if i != live {
+			old := v.ids[i]
+
+			v.ids[live] = ComponentID{index: live, version: v.ids[live].version + 1, ctype: old.ctype}
+			v.values[live] = v.values[i]
+
+			moved[old] = v.ids[live]
+		}
+
+		live++
+	}
+
+	for i := live; i < v.size; i++ {
+		v.floor = max(v.floor, v.ids[i].version)
+	}
+
+	capacity := max(live, componentPoolInitialCapacity)
+
+	newIDs := make([]ComponentID, capacity)
+	copy(newIDs, v.ids[:live])
+	v.ids = newIDs
+
+	newValues := make([]T, capacity)
+	copy(newValues, v.values[:live])
+	v.values = newValues
+
+	v.allocs++
+
+	v.size = live
+	v.free = futil.NewStack[uint32]()
+}
+
+func (v *valuePool[T, PT]) copyTo(dst componentStorage) componentStorage {
+	out, ok := dst.(*valuePool[T, PT])
+	if !ok {
+		out = &valuePool[T, PT]{ctype: v.ctype}
+	}
+
+	if cap(out.ids) >= len(v.ids) {
+		out.ids = out.ids[:len(v.ids)]
+		out.values = out.values[:len(v.values)]
+	} else {
+		out.ids = make([]ComponentID, len(v.ids))
+		out.values = make([]T, len(v.values))
+	}
+
+	copy(out.ids, v.ids)
+	copy(out.values, v.values)
+
+	// Values that need more than a plain copy are cloned individually.
+	if _, ok := Component(PT(new(T))).(ComponentCloner); ok {
+		for i := uint32(0); i < v.size; i++ {
+			if v.ids[i].isActive(i) {
+				out.values[i] = *v.unwrap(Component(PT(&v.values[i])).(ComponentCloner).Clone())
+			}
+		}
+	}
+
+	out.free = v.free.Copy()
+	out.size = v.size
+	out.allocs = v.allocs
+	out.floor = v.floor
+
+	return out
+}
+
+func (v *valuePool[T, PT]) stats() PoolStats {
+	free := uint32(v.free.Size())
+
+	return PoolStats{
+		Live:     v.size - free,
+		Size:     v.size,
+		Capacity: uint32(len(v.values)),
+		Free:     free,
+		Bytes: uint64(len(v.ids))*sizeOfComponentID +
+			uint64(len(v.values))*uint64(unsafe.Sizeof(*new(T))) +
+			uint64(free)*sizeOfFreeSlot,
+	}
+}
+
+func (v *valuePool[T, PT]) validate(vd *validator, ct ComponentType, owned map[ComponentID]EntityID) {
+	if v.ctype != ct {
+		vd.reportPool("store for type %s is registered under type %s", v.ctype.Name(), ct.Name())
+	}
+
+	validateComponentSlots(vd, ct, v.ids, len(v.values), v.size, v.free, owned, nil)
+}
+
+func (v *valuePool[T, PT]) allocations() uint32 {
+	return v.allocs
+}
+
+// unwrap returns a pointer to the T value held by the given Component.
+//
+// The given Component must be of type *T or T, otherwise this method will
+// panic.
+func (v *valuePool[T, PT]) unwrap(comp Component) *T {
+	switch value := comp.(type) {
+	case PT:
+		return value
+	case T:
+		return &value
+	default:
+		panic(fmt.Errorf("attempted to store a component of type %T in a store for values of type %T", comp, *new(T)))
+	}
+}
+
+func (v *valuePool[T, PT]) _ensureCapacity(minimum uint32) {
+	if minimum <= uint32(len(v.values)) {
+		return
+	}
+
+	newSize := max(uint32(float32(len(v.values))*componentPoolScaleFactor), minimum)
+
+	newIDs := make([]ComponentID, newSize)
+	copy(newIDs, v.ids)
+	v.ids = newIDs
+
+	newValues := make([]T, newSize)
+	copy(newValues, v.values)
+	v.values = newValues
+
+	v.allocs++
+}
@@ -0,0 +1,162 @@
+package fecs_test
+
+import (
+	"testing"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+func TestStoreAttach(t *testing.T) {
+	scene := fecs.NewScene()
+	store := fecs.NewStore[Position](scene)
+	id := scene.NewEntity()
+
+	cid, err := store.Attach(&id, Position{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = store.Attach(&id, Position{3, 4}); err == nil {
+		t.Error("expected attaching a second Position to fail")
+	}
+
+	if got, ok := store.Get(&cid); !ok || *got != (Position{1, 2}) {
+		t.Errorf("Get() = %v, %t, want {1 2}, true", got, ok)
+	}
+
+	if got, ok := store.GetByEntity(&id); !ok || *got != (Position{1, 2}) {
+		t.Errorf("GetByEntity() = %v, %t, want {1 2}, true", got, ok)
+	}
+
+	if store.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", store.Len())
+	}
+
+	fecstest.AssertHasComponent(t, scene, id, positionType)
+}
+
+func TestStoreSceneAttachCopiesIntoStore(t *testing.T) {
+	scene := fecs.NewScene()
+	store := fecs.NewStore[Position](scene)
+	id := scene.NewEntity()
+
+	scene.AttachComponent(&id, newPosition(5, 6))
+
+	got, ok := store.GetByEntity(&id)
+	if !ok || *got != (Position{5, 6}) {
+		t.Fatalf("GetByEntity() = %v, %t, want {5 6}, true", got, ok)
+	}
+
+	comp, _ := scene.GetComponentByType(&id, positionType)
+	if comp.(*Position) != got {
+		t.Error("expected the Scene to return a pointer into the Store")
+	}
+}
+
+func TestStoreEach(t *testing.T) {
+	scene := fecs.NewScene()
+	store := fecs.NewStore[Position](scene)
+	ids := scene.SpawnBatch(3, newPosition(1, 1))
+	scene.AttachComponent(&ids[1], newVelocity(0, 0))
+
+	seq := store.Each(velocityType)
+
+	// Ranging the same sequence twice must see the same entities both times.
+	for range 2 {
+		var got []fecs.EntityID
+		for id, pos := range seq {
+			got = append(got, id)
+			pos.X = 9
+		}
+
+		if len(got) != 1 || got[0] != ids[1] {
+			t.Fatalf("Each(Velocity) = %v, want [%s]", got, ids[1].String())
+		}
+	}
+
+	if pos, _ := store.GetByEntity(&ids[1]); pos.X != 9 {
+		t.Errorf("expected values yielded by Each to point into the Store")
+	}
+}
+
+func TestStoreJournal(t *testing.T) {
+	tests := []struct {
+		name   string
+		attach func(scene fecs.Scene, store *fecs.Store[Position, *Position]) fecs.EntityID
+	}{
+		{"Store.Attach", func(scene fecs.Scene, store *fecs.Store[Position, *Position]) fecs.EntityID {
+			id := scene.NewEntity()
+			store.Attach(&id, Position{3, 4})
+			return id
+		}},
+		{"Scene.AttachComponent", func(scene fecs.Scene, _ *fecs.Store[Position, *Position]) fecs.EntityID {
+			id := scene.NewEntity()
+			scene.AttachComponent(&id, newPosition(3, 4))
+			return id
+		}},
+		{"Scene.SpawnBatch", func(scene fecs.Scene, _ *fecs.Store[Position, *Position]) fecs.EntityID {
+			return scene.SpawnBatch(2, newPosition(3, 4))[1]
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene := fecs.NewScene()
+			store := fecs.NewStore[Position](scene)
+			journal := fecs.NewJournal(scene)
+
+			journal.Begin("attach")
+			id := test.attach(scene, store)
+			journal.Commit()
+
+			// Overwrite the slot so that a history holding a pointer into the
+			// Store instead of a copy would see the change.
+			pos, _ := store.GetByEntity(&id)
+			pos.X = 100
+
+			if _, ok := journal.Undo(); !ok {
+				t.Fatal("expected Undo to succeed")
+			}
+
+			fecstest.AssertEntityCount(t, scene, 0)
+
+			if _, ok := journal.Redo(); !ok {
+				t.Fatal("expected Redo to succeed")
+			}
+
+			got, ok := store.GetByEntity(&id)
+			if !ok || *got != (Position{3, 4}) {
+				t.Errorf("after Redo, GetByEntity() = %v, %t, want {3 4}, true", got, ok)
+			}
+
+			if v := scene.Validate(); len(v) > 0 {
+				t.Errorf("Validate() = %v", v)
+			}
+		})
+	}
+}
+
+func TestStoreRestoreState(t *testing.T) {
+	scene := fecs.NewScene()
+	store := fecs.NewStore[Position](scene)
+	id := scene.NewEntity()
+	store.Attach(&id, Position{1, 1})
+
+	state := fecs.CaptureState(scene)
+
+	pos, _ := store.GetByEntity(&id)
+	pos.X = 50
+	other := scene.NewEntity()
+	store.Attach(&other, Position{2, 2})
+
+	fecs.RestoreState(scene, state)
+
+	if got, _ := store.GetByEntity(&id); got.X != 1 {
+		t.Errorf("after RestoreState, X = %v, want 1", got.X)
+	}
+
+	if store.Len() != 1 {
+		t.Errorf("after RestoreState, Len() = %d, want 1", store.Len())
+	}
+}
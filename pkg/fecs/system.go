@@ -0,0 +1,93 @@
+package fecs
+
+import "time"
+
+// System defines a unit of logic that is run against the entities of a Scene
+// once per update.
+type System interface {
+	// Name returns a human-readable name for this System.
+	//
+	// System names are used to identify Systems in metrics and debugging output.
+	Name() string
+
+	// Update runs this System once against the given Scene.
+	//
+	// The given duration is the amount of simulation time that has elapsed since
+	// the previous update.
+	Update(scene Scene, dt time.Duration)
+}
+
+// NewSystem creates a new System with the given name that calls the given
+// function on each update.
+func NewSystem(name string, update func(scene Scene, dt time.Duration)) System {
+	return &funcSystem{name, update}
+}
+
+type funcSystem struct {
+	name   string
+	update func(Scene, time.Duration)
+}
+
+func (f *funcSystem) Name() string {
+	return f.name
+}
+
+func (f *funcSystem) Update(scene Scene, dt time.Duration) {
+	f.update(scene, dt)
+}
+
+// NewSystemGroup creates a new SystemGroup with the given name containing the
+// given Systems.
+func NewSystemGroup(name string, systems ...System) *SystemGroup {
+	return &SystemGroup{name: name, systems: systems}
+}
+
+// SystemGroup is a System that runs an ordered list of Systems.
+//
+// If a Metrics instance is attached to the SystemGroup, the wall time of each
+// System update is recorded, and the Metrics' Scene is sampled at the end of
+// each update.
+type SystemGroup struct {
+	name    string
+	systems []System
+	metrics *Metrics
+}
+
+// Add appends the given System to the end of this SystemGroup.
+func (g *SystemGroup) Add(system System) {
+	g.systems = append(g.systems, system)
+}
+
+// Systems returns the Systems in this SystemGroup, in the order in which they
+// are run.
+func (g *SystemGroup) Systems() []System {
+	return g.systems
+}
+
+// SetMetrics attaches the given Metrics to this SystemGroup.  Passing nil
+// detaches any previously attached Metrics.
+func (g *SystemGroup) SetMetrics(metrics *Metrics) {
+	g.metrics = metrics
+}
+
+func (g *SystemGroup) Name() string {
+	return g.name
+}
+
+func (g *SystemGroup) Update(scene Scene, dt time.Duration) {
+	if g.metrics == nil {
+		for _, sys := range g.systems {
+			sys.Update(scene, dt)
+		}
+
+		return
+	}
+
+	for _, sys := range g.systems {
+		start := time.Now()
+		sys.Update(scene, dt)
+		g.metrics.ObserveSystem(sys.Name(), time.Since(start))
+	}
+
+	g.metrics.Sample()
+}
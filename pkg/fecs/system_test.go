@@ -0,0 +1,77 @@
+package fecs_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+)
+
+func TestSystemGroup(t *testing.T) {
+	scene := fecs.NewScene()
+
+	var ran []string
+	record := func(name string) fecs.System {
+		return fecs.NewSystem(name, func(s fecs.Scene, dt time.Duration) {
+			if dt != time.Second {
+				t.Errorf("%s given dt %s", name, dt)
+			}
+
+			ran = append(ran, name)
+			s.NewEntity()
+		})
+	}
+
+	inner := fecs.NewSystemGroup("inner", record("c"))
+	group := fecs.NewSystemGroup("outer", record("a"), inner)
+	group.Add(record("b"))
+
+	if group.Name() != "outer" || len(group.Systems()) != 3 {
+		t.Errorf("Name(), len(Systems()) = %s, %d", group.Name(), len(group.Systems()))
+	}
+
+	group.Update(scene, time.Second)
+
+	if want := []string{"a", "c", "b"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+
+	metrics := fecs.NewMetrics(scene)
+	group.SetMetrics(metrics)
+	group.Update(scene, time.Second)
+	group.Update(scene, time.Second)
+
+	report := metrics.Report()
+
+	var names []string
+	for _, sys := range report.Systems {
+		names = append(names, sys.Name)
+
+		if sys.Count != 2 {
+			t.Errorf("%s observed %d times, want 2", sys.Name, sys.Count)
+		}
+	}
+
+	// The nested group is timed as a whole, without metrics of its own.
+	if want := []string{"a", "b", "inner"}; !slices.Equal(names, want) {
+		t.Errorf("observed Systems %v, want %v", names, want)
+	}
+
+	// The scene is sampled at the end of each update.
+	if report.Entities.Live != 9 || report.Operations.EntitiesCreated != 6 {
+		t.Errorf("Entities.Live, EntitiesCreated = %d, %d, want 9, 6", report.Entities.Live, report.Operations.EntitiesCreated)
+	}
+
+	group.SetMetrics(nil)
+	group.Update(scene, time.Second)
+
+	report = metrics.Report()
+	if report.Systems[0].Count != 2 || report.Entities.Live != 9 {
+		t.Errorf("detached group was still recorded: %+v", report)
+	}
+
+	if len(ran) != 12 {
+		t.Errorf("ran %d systems, want 12", len(ran))
+	}
+}
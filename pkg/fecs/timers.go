@@ -0,0 +1,342 @@
+package fecs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
+)
+
+// NewTimers creates a new Timers instance that expires Components and entities
+// of the given Scene.
+//
+// The given Scene must have been created by NewScene, otherwise this function
+// will panic.
+func NewTimers(scene Scene) *Timers {
+	impl := requireSceneImpl(scene, "attach timers to")
+
+	out := &Timers{
+		scene:   impl,
+		byTime:  futil.NewPriorityQueue(timerLess),
+		byTick:  futil.NewPriorityQueue(timerLess),
+		pending: make(map[timerTarget]uint64, 32),
+	}
+
+	impl.addListener(out)
+
+	return out
+}
+
+// Timers is a System that removes Components from, or destroys, entities of a
+// Scene once a given amount of time or number of ticks has passed.
+//
+// Timers is driven by its Update method, which should be run once per tick,
+// typically as part of a SystemGroup or by a Runner.  Each call to Update
+// advances the Timers by one tick and by the given duration, then expires
+// everything that has come due: first the duration based timers, then the tick
+// based timers, each in the order they came due.
+//
+// Each Component or entity may have at most one pending timer; scheduling a new
+// timer for the same target replaces the previous one.  Timers whose targets
+// are removed or destroyed by other means before they come due are cancelled,
+// and are not brought back if the removal is undone by a Journal.  Timers follow
+// their targets to their new IDs when the Scene is compacted.
+type Timers struct {
+	scene   *scene
+	now     time.Duration
+	tick    uint64
+	seq     uint64
+	byTime  futil.PriorityQueue[timer]
+	byTick  futil.PriorityQueue[timer]
+	pending map[timerTarget]uint64
+}
+
+// Name implements System.Name.
+func (t *Timers) Name() string {
+	return "timers"
+}
+
+// Update advances these Timers by one tick and by the given duration, then
+// expires every Component and entity whose timer has come due.
+//
+// The given Scene is ignored; Timers always operate on the Scene they were
+// created for.
+func (t *Timers) Update(_ Scene, dt time.Duration) {
+	t.tick++
+	t.now += dt
+
+	t.expire(t.byTime, int64(t.now))
+	t.expire(t.byTick, int64(t.tick))
+}
+
+// Elapsed returns the total duration these Timers have been advanced by.
+func (t *Timers) Elapsed() time.Duration {
+	return t.now
+}
+
+// Ticks returns the number of times these Timers have been updated.
+func (t *Timers) Ticks() uint64 {
+	return t.tick
+}
+
+// Pending returns the number of timers that have not yet come due or been
+// cancelled.
+func (t *Timers) Pending() int {
+	return len(t.pending)
+}
+
+// AttachFor attaches a new Component created by the given constructor to the
+// entity identified by the given EntityID, and removes it again once the given
+// duration has elapsed.
+//
+// Returns an error wrapping ErrWrongScene or ErrEntityNotFound if the target
+// entity is not in the Scene, or ErrDuplicateComponent if the target entity
+// already has a Component of the same ComponentType attached.
+func (t *Timers) AttachFor(eid *EntityID, ttl time.Duration, constructor ComponentConstructor) (ComponentID, error) {
+	cid, err := t.scene.TryAttachComponent(eid, constructor)
+	if err != nil {
+		return cid, err
+	}
+
+	t.schedule(t.byTime, timerTarget{eid: *eid, cid: cid}, int64(t.now+ttl))
+
+	return cid, nil
+}
+
+// AttachForTicks attaches a new Component created by the given constructor to
+// the entity identified by the given EntityID, and removes it again after the
+// given number of ticks.
+//
+// Returns an error wrapping ErrWrongScene or ErrEntityNotFound if the target
+// entity is not in the Scene, or ErrDuplicateComponent if the target entity
+// already has a Component of the same ComponentType attached.
+func (t *Timers) AttachForTicks(eid *EntityID, ticks uint64, constructor ComponentConstructor) (ComponentID, error) {
+	cid, err := t.scene.TryAttachComponent(eid, constructor)
+	if err != nil {
+		return cid, err
+	}
+
+	t.schedule(t.byTick, timerTarget{eid: *eid, cid: cid}, t.tickDeadline(ticks))
+
+	return cid, nil
+}
+
+// ExpireComponent removes the target Component from the entity identified by
+// the given EntityID once the given duration has elapsed.
+//
+// Returns an error wrapping ErrComponentNotFound if the target Component is not
+// attached to the target entity.
+func (t *Timers) ExpireComponent(eid *EntityID, cid *ComponentID, ttl time.Duration) error {
+	if !t.scene.HasComponent(eid, cid) {
+		return fmt.Errorf("cannot expire component %s of entity %s: %w", cid.String(), eid.String(), ErrComponentNotFound)
+	}
+
+	t.schedule(t.byTime, timerTarget{eid: *eid, cid: *cid}, int64(t.now+ttl))
+
+	return nil
+}
+
+// ExpireComponentTicks removes the target Component from the entity identified
+// by the given EntityID after the given number of ticks.
+//
+// Returns an error wrapping ErrComponentNotFound if the target Component is not
+// attached to the target entity.
+func (t *Timers) ExpireComponentTicks(eid *EntityID, cid *ComponentID, ticks uint64) error {
+	if !t.scene.HasComponent(eid, cid) {
+		return fmt.Errorf("cannot expire component %s of entity %s: %w", cid.String(), eid.String(), ErrComponentNotFound)
+	}
+
+	t.schedule(t.byTick, timerTarget{eid: *eid, cid: *cid}, t.tickDeadline(ticks))
+
+	return nil
+}
+
+// ExpireEntity destroys the entity identified by the given EntityID once the
+// given duration has elapsed.
+//
+// Returns an error wrapping ErrEntityNotFound if the target entity is not in
+// the Scene.
+func (t *Timers) ExpireEntity(eid *EntityID, ttl time.Duration) error {
+	if !t.scene.ContainsEntity(eid) {
+		return fmt.Errorf("cannot expire entity %s: %w", eid.String(), ErrEntityNotFound)
+	}
+
+	t.schedule(t.byTime, timerTarget{eid: *eid, entity: true}, int64(t.now+ttl))
+
+	return nil
+}
+
+// ExpireEntityTicks destroys the entity identified by the given EntityID after
+// the given number of ticks.
+//
+// Returns an error wrapping ErrEntityNotFound if the target entity is not in
+// the Scene.
+func (t *Timers) ExpireEntityTicks(eid *EntityID, ticks uint64) error {
+	if !t.scene.ContainsEntity(eid) {
+		return fmt.Errorf("cannot expire entity %s: %w", eid.String(), ErrEntityNotFound)
+	}
+
+	t.schedule(t.byTick, timerTarget{eid: *eid, entity: true}, t.tickDeadline(ticks))
+
+	return nil
+}
+
+// CancelComponent cancels the pending timer for the target Component of the
+// entity identified by the given EntityID.
+//
+// Returns a boolean value indicating whether a timer was pending.
+func (t *Timers) CancelComponent(eid *EntityID, cid *ComponentID) bool {
+	return t.cancel(timerTarget{eid: *eid, cid: *cid})
+}
+
+// CancelEntity cancels the pending timer for the entity identified by the
+// given EntityID.
+//
+// Timers for the individual Components of the target entity are not affected.
+//
+// Returns a boolean value indicating whether a timer was pending.
+func (t *Timers) CancelEntity(eid *EntityID) bool {
+	return t.cancel(timerTarget{eid: *eid, entity: true})
+}
+
+// Clear cancels all pending timers.
+func (t *Timers) Clear() {
+	t.byTime.Clear()
+	t.byTick.Clear()
+	clear(t.pending)
+}
+
+// Close stops these Timers from watching their Scene and cancels all pending
+// timers.
+func (t *Timers) Close() {
+	t.scene.removeListener(t)
+	t.Clear()
+}
+
+func (t *Timers) entityCreated(*EntityID) {}
+
+func (t *Timers) entityDestroyed(eid *EntityID, comps []componentRecord) {
+	delete(t.pending, timerTarget{eid: *eid, entity: true})
+
+	for i := range comps {
+		delete(t.pending, timerTarget{eid: *eid, cid: comps[i].id})
+	}
+}
+
+func (t *Timers) componentAttached(*EntityID, *ComponentID, Component) {}
+
+func (t *Timers) componentRemoved(eid *EntityID, cid *ComponentID, _ Component) {
+	delete(t.pending, timerTarget{eid: *eid, cid: *cid})
+}
+
+func (t *Timers) componentReplaced(*ComponentID, Component, Component) {}
+
+func (t *Timers) sceneCompacted(remap *Remapping) {
+	pending := make(map[timerTarget]uint64, len(t.pending))
+	for target, seq := range t.pending {
+		pending[target.remap(remap)] = seq
+	}
+
+	t.pending = pending
+
+	t.remapQueue(t.byTime, remap)
+	t.remapQueue(t.byTick, remap)
+}
+
+// remapQueue rewrites the targets of the timers in the given queue to their IDs
+// after compaction, dropping any timers that were cancelled or replaced.
+func (t *Timers) remapQueue(queue futil.PriorityQueue[timer], remap *Remapping) {
+	live := make([]timer, 0, queue.Size())
+
+	for !queue.IsEmpty() {
+		next := queue.Pop()
+		next.target = next.target.remap(remap)
+
+		if seq, ok := t.pending[next.target]; ok && seq == next.seq {
+			live = append(live, next)
+		}
+	}
+
+	for i := range live {
+		queue.Push(live[i])
+	}
+}
+
+// tickDeadline returns the tick on which a timer scheduled now to run after the
+// given number of ticks comes due.  Timers always run on a later tick than the
+// one they were scheduled in.
+func (t *Timers) tickDeadline(ticks uint64) int64 {
+	return int64(t.tick + max(ticks, 1))
+}
+
+func (t *Timers) schedule(queue futil.PriorityQueue[timer], target timerTarget, at int64) {
+	t.seq++
+	t.pending[target] = t.seq
+	queue.Push(timer{target: target, at: at, seq: t.seq})
+}
+
+func (t *Timers) cancel(target timerTarget) bool {
+	if _, ok := t.pending[target]; !ok {
+		return false
+	}
+
+	// The queued timer is left in place and skipped when it comes due.
+	delete(t.pending, target)
+
+	return true
+}
+
+func (t *Timers) expire(queue futil.PriorityQueue[timer], now int64) {
+	for !queue.IsEmpty() && queue.Peek().at <= now {
+		next := queue.Pop()
+
+		// Skip timers that were cancelled or replaced.
+		if seq, ok := t.pending[next.target]; !ok || seq != next.seq {
+			continue
+		}
+
+		delete(t.pending, next.target)
+
+		if next.target.entity {
+			t.scene.TryDestroyEntity(&next.target.eid)
+		} else {
+			t.scene.TryRemoveComponent(&next.target.eid, &next.target.cid)
+		}
+	}
+}
+
+// timerTarget identifies the entity or Component a timer applies to.
+type timerTarget struct {
+	eid    EntityID
+	cid    ComponentID
+	entity bool
+}
+
+// remap returns this target with its IDs rewritten to the IDs they were moved
+// to by the given Remapping.
+func (t timerTarget) remap(remap *Remapping) timerTarget {
+	t.eid, _ = remap.Entity(&t.eid)
+
+	if !t.entity {
+		t.cid, _ = remap.Component(&t.cid)
+	}
+
+	return t
+}
+
+type timer struct {
+	target timerTarget
+
+	// at holds the elapsed time in nanoseconds or the tick number at which the
+	// timer comes due, depending on which queue the timer is in.
+	at int64
+
+	// seq orders timers that come due at the same time by the order in which
+	// they were scheduled, and identifies the latest timer scheduled for a
+	// target.
+	seq uint64
+}
+
+func timerLess(a, b timer) bool {
+	return a.at < b.at || a.at == b.at && a.seq < b.seq
+}
@@ -0,0 +1,197 @@
+package fecs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs"
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/fecstest"
+)
+
+func TestTimersExpire(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// schedule sets up timers on the given entity, returning the number of
+		// Update calls, each advancing by one second, after which the entity
+		// should have been destroyed or lost its Velocity.
+		schedule func(t *testing.T, timers *fecs.Timers, scene fecs.Scene, id fecs.EntityID) int
+
+		// destroys indicates whether the timer destroys the entity rather than
+		// removing its Velocity.
+		destroys bool
+	}{
+		{"AttachFor", func(t *testing.T, timers *fecs.Timers, _ fecs.Scene, id fecs.EntityID) int {
+			_, err := timers.AttachFor(&id, 3*time.Second, newVelocity(1, 0))
+			mustNoErr(t, err)
+			return 3
+		}, false},
+		{"AttachForTicks", func(t *testing.T, timers *fecs.Timers, _ fecs.Scene, id fecs.EntityID) int {
+			_, err := timers.AttachForTicks(&id, 2, newVelocity(1, 0))
+			mustNoErr(t, err)
+			return 2
+		}, false},
+		{"ExpireComponent", func(t *testing.T, timers *fecs.Timers, scene fecs.Scene, id fecs.EntityID) int {
+			cid := scene.AttachComponent(&id, newVelocity(1, 0))
+			mustNoErr(t, timers.ExpireComponent(&id, &cid, 1500*time.Millisecond))
+			return 2
+		}, false},
+		{"ExpireComponentTicks zero", func(t *testing.T, timers *fecs.Timers, scene fecs.Scene, id fecs.EntityID) int {
+			cid := scene.AttachComponent(&id, newVelocity(1, 0))
+			mustNoErr(t, timers.ExpireComponentTicks(&id, &cid, 0))
+			return 1
+		}, false},
+		{"ExpireEntity", func(t *testing.T, timers *fecs.Timers, _ fecs.Scene, id fecs.EntityID) int {
+			mustNoErr(t, timers.ExpireEntity(&id, 4*time.Second))
+			return 4
+		}, true},
+		{"ExpireEntityTicks", func(t *testing.T, timers *fecs.Timers, _ fecs.Scene, id fecs.EntityID) int {
+			mustNoErr(t, timers.ExpireEntityTicks(&id, 3))
+			return 3
+		}, true},
+		{"rescheduled", func(t *testing.T, timers *fecs.Timers, _ fecs.Scene, id fecs.EntityID) int {
+			mustNoErr(t, timers.ExpireEntity(&id, time.Second))
+			mustNoErr(t, timers.ExpireEntity(&id, 5*time.Second))
+			return 5
+		}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene, ids := fecstest.NewScene(t, fecstest.Fixture{"e": {&Position{}}})
+			id := ids["e"]
+			timers := fecs.NewTimers(scene)
+
+			due := test.schedule(t, timers, scene, id)
+
+			for tick := 1; tick <= due; tick++ {
+				timers.Update(scene, time.Second)
+
+				alive := scene.ContainsEntity(&id)
+				_, moving := scene.GetComponentByType(&id, velocityType)
+				expired := tick == due
+
+				if test.destroys && alive == expired {
+					t.Fatalf("after tick %d, alive = %t", tick, alive)
+				}
+
+				if !test.destroys && moving == expired {
+					t.Fatalf("after tick %d, has Velocity = %t", tick, moving)
+				}
+			}
+
+			if timers.Pending() != 0 {
+				t.Errorf("Pending() = %d, want 0", timers.Pending())
+			}
+		})
+	}
+}
+
+func TestTimersCancelledByScene(t *testing.T) {
+	tests := []struct {
+		name   string
+		cancel func(scene fecs.Scene, timers *fecs.Timers, id fecs.EntityID, cid fecs.ComponentID)
+	}{
+		{"DestroyEntity", func(scene fecs.Scene, _ *fecs.Timers, id fecs.EntityID, _ fecs.ComponentID) {
+			scene.DestroyEntity(&id)
+		}},
+		{"RemoveComponent", func(scene fecs.Scene, _ *fecs.Timers, id fecs.EntityID, cid fecs.ComponentID) {
+			scene.RemoveComponent(&id, &cid)
+			scene.DestroyEntity(&id)
+		}},
+		{"CancelComponent", func(scene fecs.Scene, timers *fecs.Timers, id fecs.EntityID, cid fecs.ComponentID) {
+			timers.CancelComponent(&id, &cid)
+			timers.CancelEntity(&id)
+		}},
+		{"Clear", func(_ fecs.Scene, timers *fecs.Timers, _ fecs.EntityID, _ fecs.ComponentID) {
+			timers.Clear()
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scene := fecs.NewScene()
+			timers := fecs.NewTimers(scene)
+			id := scene.NewEntity()
+
+			cid, err := timers.AttachFor(&id, time.Second, newVelocity(0, 0))
+			mustNoErr(t, err)
+			mustNoErr(t, timers.ExpireEntity(&id, time.Second))
+
+			test.cancel(scene, timers, id, cid)
+
+			if timers.Pending() != 0 {
+				t.Errorf("Pending() = %d, want 0", timers.Pending())
+			}
+		})
+	}
+}
+
+func TestTimersFollowCompaction(t *testing.T) {
+	scene := fecs.NewScene()
+	timers := fecs.NewTimers(scene)
+
+	ids := scene.SpawnBatch(4, newPosition(0, 0))
+	scene.DestroyEntity(&ids[0])
+	scene.DestroyEntity(&ids[1])
+
+	// Both remaining entities, and their components, are moved by compaction.
+	doomed, slowed := ids[2], ids[3]
+	mustNoErr(t, timers.ExpireEntity(&doomed, time.Second))
+	_, err := timers.AttachForTicks(&slowed, 1, newVelocity(1, 1))
+	mustNoErr(t, err)
+
+	remap := scene.Compact()
+	if remap.MovedEntities() != 2 {
+		t.Fatalf("MovedEntities() = %d, want 2", remap.MovedEntities())
+	}
+
+	doomed, _ = remap.Entity(&doomed)
+	slowed, _ = remap.Entity(&slowed)
+
+	if timers.Pending() != 2 {
+		t.Fatalf("after Compact, Pending() = %d, want 2", timers.Pending())
+	}
+
+	timers.Update(scene, 2*time.Second)
+
+	if scene.ContainsEntity(&doomed) {
+		t.Error("expected the moved entity to be destroyed")
+	}
+
+	fecstest.AssertNoComponent(t, scene, slowed, velocityType)
+
+	if timers.Pending() != 0 {
+		t.Errorf("Pending() = %d, want 0", timers.Pending())
+	}
+}
+
+func TestTimersErrors(t *testing.T) {
+	scene := fecs.NewScene()
+	timers := fecs.NewTimers(scene)
+	id := scene.NewEntity()
+	scene.DestroyEntity(&id)
+
+	if err := timers.ExpireEntity(&id, time.Second); err == nil {
+		t.Error("expected ExpireEntity on a destroyed entity to fail")
+	}
+
+	if _, err := timers.AttachFor(&id, time.Second, newVelocity(0, 0)); err == nil {
+		t.Error("expected AttachFor on a destroyed entity to fail")
+	}
+
+	live := scene.NewEntity()
+	cid := fecs.ComponentID{}
+	if err := timers.ExpireComponent(&live, &cid, time.Second); err == nil {
+		t.Error("expected ExpireComponent on a missing component to fail")
+	}
+}
+
+// mustNoErr fails the test immediately if the given error is not nil.
+func mustNoErr(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !fecsdebug
+
+package fecs
+
+// debugValidation enables validating Scenes after every mutation.
+const debugValidation = false
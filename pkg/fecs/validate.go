@@ -0,0 +1,278 @@
+package fecs
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Foxcapades/go-ecs-toy/pkg/fecs/futil"
+)
+
+// Violation describes a single broken internal invariant of a Scene, as found
+// by Scene.Validate.
+type Violation struct {
+	// Pool names the pool the violation was found in: "entities" for the entity
+	// pool, or the name of a ComponentType for a component pool.
+	Pool string
+
+	// Slot holds the index of the pool slot the violation was found in, or -1
+	// if the violation concerns the pool as a whole.
+	Slot int
+
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Slot < 0 {
+		return v.Pool + ": " + v.Message
+	}
+
+	return v.Pool + "[" + strconv.Itoa(v.Slot) + "]: " + v.Message
+}
+
+// ValidationError is the value panicked with when a Scene fails validation
+// after a mutation in a build with the fecsdebug build tag.
+//
+// ValidationError wraps ErrIllegalState.
+type ValidationError struct {
+	// Scene holds the ID of the Scene that failed validation.
+	Scene SceneID
+
+	// Action names the mutation after which the Scene failed validation.
+	Action string
+
+	// Violations holds the violations found in the Scene.
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "scene-%x failed validation after %s with %d violation(s):", e.Scene, e.Action, len(e.Violations))
+
+	for i := range e.Violations {
+		out.WriteString("\n\t")
+		out.WriteString(e.Violations[i].String())
+	}
+
+	return out.String()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrIllegalState
+}
+
+func (s *scene) Validate() []Violation {
+	v := validator{pool: "entities"}
+
+	owned := s.validateEntities(&v)
+
+	types := make([]ComponentType, 0, len(s.components))
+	for ct := range s.components {
+		types = append(types, ct)
+	}
+
+	slices.Sort(types)
+
+	for _, ct := range types {
+		v.pool = ct.Name()
+		s.components[ct].validate(&v, ct, owned)
+	}
+
+	return v.out
+}
+
+// checkInvariants panics with a *ValidationError if debug validation is
+// enabled and this scene fails validation after the named action.
+func (s *scene) checkInvariants(action string) {
+	if !debugValidation {
+		return
+	}
+
+	if violations := s.Validate(); len(violations) > 0 {
+		panic(&ValidationError{Scene: s.sceneID, Action: action, Violations: violations})
+	}
+}
+
+// validateEntities checks the invariants of the entity pool, and of every
+// living entity's component references.
+//
+// Returns the ComponentIDs referenced by living entities, mapped to the entity
+// referencing them.
+func (s *scene) validateEntities(v *validator) map[ComponentID]EntityID {
+	pool := &s.entities
+	owned := make(map[ComponentID]EntityID, 64)
+
+	size := v.checkSize(pool.size, len(pool.pool))
+	free := v.checkFreeList(pool.free, pool.size, func(i uint32) bool { return i < size && pool.pool[i].isLiving(i) })
+
+	for i := uint32(0); i < size; i++ {
+		ent := &pool.pool[i]
+
+		if !ent.isLiving(i) {
+			if !free[i] {
+				v.report(i, "dead entity slot is not on the free list")
+			}
+
+			if !ent.mask.isEmpty() {
+				v.report(i, "dead entity slot still has a component mask")
+			}
+
+			if len(ent.comps) > 0 {
+				v.report(i, "dead entity slot still references %d component(s)", len(ent.comps))
+			}
+
+			continue
+		}
+
+		if ent.id.scene != s.sceneID {
+			v.report(i, "entity %s belongs to scene-%x", ent.id.String(), ent.id.scene)
+		}
+
+		if ent.id.version == 0 {
+			v.report(i, "entity %s has version 0", ent.id.String())
+		}
+
+		seen := componentMask{}
+
+		for j, ref := range ent.comps {
+			if ref == nil {
+				v.report(i, "entity %s has a nil component reference at position %d", ent.id.String(), j)
+				continue
+			}
+
+			if seen.has(ref.ctype) {
+				v.report(i, "entity %s references multiple components of type %s", ent.id.String(), ref.ctype.Name())
+			}
+
+			seen.add(ref.ctype)
+
+			if !ent.mask.has(ref.ctype) {
+				v.report(i, "entity %s references component %s but its component mask is missing type %s", ent.id.String(), ref.String(), ref.ctype.Name())
+			}
+
+			if storage, ok := s.components[ref.ctype]; !ok || !storage.containsComponent(ref) {
+				v.report(i, "entity %s references component %s which is not live in its pool", ent.id.String(), ref.String())
+				continue
+			}
+
+			if other, ok := owned[*ref]; ok {
+				v.report(i, "entity %s references component %s which is also referenced by entity %s", ent.id.String(), ref.String(), other.String())
+				continue
+			}
+
+			owned[*ref] = ent.id
+		}
+
+		for ct := 1; ct <= 255; ct++ {
+			if ent.mask.has(ComponentType(ct)) && !seen.has(ComponentType(ct)) {
+				v.report(i, "entity %s has type %s in its component mask but no component of that type", ent.id.String(), ComponentType(ct).Name())
+			}
+		}
+	}
+
+	return owned
+}
+
+// validateComponentSlots checks the invariants shared by every componentStorage
+// implementation.
+//
+// The given hasValue function tests whether the slot at the given index holds
+// a Component value; it may be nil for storages whose slots always hold one.
+func validateComponentSlots(
+	v *validator,
+	ct ComponentType,
+	ids []ComponentID,
+	capacity int,
+	size uint32,
+	free futil.Stack[uint32],
+	owned map[ComponentID]EntityID,
+	hasValue func(i uint32) bool,
+) {
+	n := v.checkSize(size, min(len(ids), capacity))
+	freeSlots := v.checkFreeList(free, size, func(i uint32) bool { return i < n && ids[i].isActive(i) })
+
+	for i := uint32(0); i < n; i++ {
+		id := &ids[i]
+
+		if !id.isActive(i) {
+			if !freeSlots[i] {
+				v.report(i, "dead component slot is not on the free list")
+			}
+
+			if hasValue != nil && hasValue(i) {
+				v.report(i, "dead component slot still holds a component")
+			}
+
+			continue
+		}
+
+		if id.ctype != ct {
+			v.report(i, "component %s has type %s", id.String(), id.ctype.Name())
+		}
+
+		if hasValue != nil && !hasValue(i) {
+			v.report(i, "component %s has no value", id.String())
+		}
+
+		if _, ok := owned[*id]; !ok {
+			v.report(i, "component %s is not attached to any entity", id.String())
+		}
+	}
+}
+
+// validator collects the Violations found by Scene.Validate.
+type validator struct {
+	pool string
+	out  []Violation
+}
+
+// report records a violation in the slot at the given index of the current
+// pool.
+func (v *validator) report(slot uint32, format string, args ...any) {
+	v.out = append(v.out, Violation{Pool: v.pool, Slot: int(slot), Message: fmt.Sprintf(format, args...)})
+}
+
+// reportPool records a violation concerning the current pool as a whole.
+func (v *validator) reportPool(format string, args ...any) {
+	v.out = append(v.out, Violation{Pool: v.pool, Slot: -1, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkSize tests that the given pool size does not exceed the given capacity.
+//
+// Returns the number of slots that may safely be inspected.
+func (v *validator) checkSize(size uint32, capacity int) uint32 {
+	if int64(size) > int64(capacity) {
+		v.reportPool("size %d exceeds capacity %d", size, capacity)
+		return uint32(capacity)
+	}
+
+	return size
+}
+
+// checkFreeList tests that every entry on the given free list is unique and
+// refers to a dead slot below the given pool size.
+//
+// Returns the set of slots on the free list.
+func (v *validator) checkFreeList(free futil.Stack[uint32], size uint32, isLive func(i uint32) bool) map[uint32]bool {
+	out := make(map[uint32]bool, free.Size())
+
+	for entries := free.Copy(); !entries.IsEmpty(); {
+		i := entries.Pop()
+
+		switch {
+		case out[i]:
+			v.reportPool("slot %d is on the free list more than once", i)
+		case i >= size:
+			v.reportPool("free list entry %d is beyond the end of the pool (size %d)", i, size)
+		case isLive(i):
+			v.report(i, "live slot is on the free list")
+		}
+
+		out[i] = true
+	}
+
+	return out
+}
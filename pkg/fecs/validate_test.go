@@ -0,0 +1,164 @@
+package fecs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Component types used by the validation tests.  These live in package fecs so
+// that the tests can corrupt scene internals directly, and so must not share
+// names with the types of the external tests.
+var (
+	probeType = NewNamedComponentType("ValidateProbe")
+	markType  = NewNamedComponentType("ValidateMark")
+)
+
+type probe struct{}
+
+func (*probe) Type() ComponentType {
+	return probeType
+}
+
+type mark struct{}
+
+func (*mark) Type() ComponentType {
+	return markType
+}
+
+func TestSceneValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(s *scene, a, b *EntityID)
+		pool    string
+		slot    func(a, b *EntityID) int
+		message string
+	}{
+		{"clean", func(*scene, *EntityID, *EntityID) {}, "", nil, ""},
+		{"live entity on free list", func(s *scene, a, _ *EntityID) {
+			s.entities.free.Push(a.index)
+		}, "entities", entitySlot, "live slot is on the free list"},
+		{"dead entity not on free list", func(s *scene, _, b *EntityID) {
+			s.DestroyEntity(b)
+			s.entities.free.Pop()
+		}, "entities", otherSlot, "dead entity slot is not on the free list"},
+		{"duplicate free list entry", func(s *scene, _, b *EntityID) {
+			s.DestroyEntity(b)
+			s.entities.free.Push(b.index)
+		}, "entities", poolWide, "more than once"},
+		{"free list entry beyond the pool", func(s *scene, _, _ *EntityID) {
+			s.entities.free.Push(100)
+		}, "entities", poolWide, "beyond the end of the pool"},
+		{"size beyond capacity", func(s *scene, _, _ *EntityID) {
+			s.entities.size = uint32(len(s.entities.pool)) + 1
+		}, "entities", poolWide, "exceeds capacity"},
+		{"foreign entity", func(s *scene, a, _ *EntityID) {
+			s.entities.pool[a.index].id.scene = s.sceneID + 1
+		}, "entities", entitySlot, "belongs to scene"},
+		{"mask without component", func(s *scene, a, _ *EntityID) {
+			s.entities.pool[a.index].mask.add(markType)
+		}, "entities", entitySlot, "no component of that type"},
+		{"component without mask", func(s *scene, a, _ *EntityID) {
+			s.entities.pool[a.index].mask.remove(probeType)
+		}, "entities", entitySlot, "component mask is missing type ValidateProbe"},
+		{"shared component", func(s *scene, a, b *EntityID) {
+			ent := &s.entities.pool[b.index]
+			ent.comps = append(ent.comps, s.entities.pool[a.index].comps[0])
+			ent.mask.add(probeType)
+		}, "entities", otherSlot, "also referenced by entity"},
+		{"orphaned component", func(s *scene, _, _ *EntityID) {
+			s.components[probeType].newComponent(&probe{})
+		}, "ValidateProbe", componentSlot(1), "is not attached to any entity"},
+		{"dead component holding a value", func(s *scene, a, _ *EntityID) {
+			cid := *s.entities.pool[a.index].comps[0]
+			s.RemoveComponent(a, &cid)
+			s.components[probeType].(*componentPool).pool[cid.index] = &probe{}
+		}, "ValidateProbe", componentSlot(0), "dead component slot still holds a component"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewScene().(*scene)
+
+			a := s.NewEntity()
+			s.AttachComponent(&a, func() Component { return &probe{} })
+
+			b := s.NewEntity()
+			s.AttachComponent(&b, func() Component { return &mark{} })
+
+			test.corrupt(s, &a, &b)
+
+			violations := s.Validate()
+
+			if test.message == "" {
+				if len(violations) > 0 {
+					t.Errorf("Validate() = %v, want no violations", violations)
+				}
+				return
+			}
+
+			want := Violation{Pool: test.pool, Slot: test.slot(&a, &b)}
+			found := false
+
+			for _, v := range violations {
+				if v.Pool == want.Pool && v.Slot == want.Slot && strings.Contains(v.Message, test.message) {
+					found = true
+				}
+			}
+
+			if !found {
+				t.Errorf("Validate() = %v, want a violation in %s slot %d containing %q", violations, want.Pool, want.Slot, test.message)
+			}
+
+			// Mutations check the scene in fecsdebug builds only.
+			defer func() {
+				r := recover()
+
+				if !debugValidation {
+					if r != nil {
+						panic(r)
+					}
+					return
+				}
+
+				err, ok := r.(*ValidationError)
+				if !ok {
+					t.Fatalf("checkInvariants() panicked with %v, want a *ValidationError", r)
+				}
+
+				if !errors.Is(err, ErrIllegalState) || err.Action != "Test" || len(err.Violations) != len(violations) {
+					t.Errorf("checkInvariants() panicked with %v", err)
+				}
+			}()
+
+			s.checkInvariants("Test")
+		})
+	}
+}
+
+func entitySlot(a, _ *EntityID) int { return int(a.index) }
+func otherSlot(_, b *EntityID) int  { return int(b.index) }
+func poolWide(_, _ *EntityID) int   { return -1 }
+
+func componentSlot(i int) func(a, b *EntityID) int {
+	return func(*EntityID, *EntityID) int { return i }
+}
+
+func TestValidationErrorFormat(t *testing.T) {
+	err := &ValidationError{
+		Scene:  0x1f,
+		Action: "DestroyEntity",
+		Violations: []Violation{
+			{Pool: "entities", Slot: -1, Message: "size 3 exceeds capacity 2"},
+			{Pool: "Position", Slot: 4, Message: "dead component slot still holds a component"},
+		},
+	}
+
+	want := "scene-1f failed validation after DestroyEntity with 2 violation(s):" +
+		"\n\tentities: size 3 exceeds capacity 2" +
+		"\n\tPosition[4]: dead component slot still holds a component"
+
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}